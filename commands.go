@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/app"
+	"github.com/borissimkin/pomogoro/pkg/command"
+	"github.com/borissimkin/pomogoro/pkg/history"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/profile"
+	"github.com/borissimkin/pomogoro/pkg/router"
+	"github.com/borissimkin/pomogoro/pkg/settings"
+	tea "github.com/charmbracelet/bubbletea"
+	"os"
+	"path/filepath"
+)
+
+// shellCommands are run from the shell, e.g. `pomogoro set work 50m`, as
+// well as from the command line of the TUI.
+func shellCommands() []command.Command {
+	return []command.Command{
+		{
+			Name:        "set",
+			Usage:       "set <name> <value>",
+			Description: "change a setting of the profile, e.g. set work 50m",
+			Complete:    completeSet,
+			Run:         runSet,
+		},
+		{
+			Name:        "export",
+			Usage:       "export csv [file]",
+			Description: "export the history of the profile",
+			Complete: func(args []string) []string {
+				if len(args) == 1 {
+					return []string{"csv"}
+				}
+				return nil
+			},
+			Run: runExport,
+		},
+	}
+}
+
+// tuiCommands need the running program.
+func tuiCommands(r *router.Router) []command.Command {
+	return []command.Command{
+		{
+			Name:        "goto",
+			Usage:       "goto <page>",
+			Description: "show a page",
+			Complete: func(args []string) []string {
+				if len(args) != 1 {
+					return nil
+				}
+
+				var pages []string
+				for _, key := range r.Keys() {
+					pages = append(pages, string(key))
+				}
+
+				return pages
+			},
+			Run: func(ctx *command.Context, args []string) error {
+				if len(args) != 1 {
+					return errors.New("usage: goto <page>")
+				}
+
+				ctx.Do(r.Goto(router.RouteKey(args[0])))
+
+				return nil
+			},
+		},
+		{
+			Name:        "profile",
+			Usage:       "profile <name>",
+			Description: "switch to a profile",
+			Complete: func(args []string) []string {
+				if len(args) != 1 {
+					return nil
+				}
+
+				names, _ := profile.List()
+
+				return names
+			},
+			Run: func(ctx *command.Context, args []string) error {
+				if len(args) != 1 {
+					return errors.New("usage: profile <name>")
+				}
+
+				if err := profile.Validate(args[0]); err != nil {
+					return err
+				}
+				if !profile.Exists(args[0]) {
+					return fmt.Errorf("%w: %s", profile.ErrNotExists, args[0])
+				}
+
+				if err := profile.Set(args[0]); err != nil {
+					return err
+				}
+
+				ctx.Do(r.Goto(app.MainPageName))
+
+				return nil
+			},
+		},
+		{
+			Name:        "quit",
+			Description: "quit pomogoro",
+			Run: func(ctx *command.Context, _ []string) error {
+				ctx.Do(tea.Quit)
+
+				return nil
+			},
+		},
+	}
+}
+
+func completeSet(args []string) []string {
+	switch len(args) {
+	case 1:
+		return settings.Names()
+	case 2:
+		return settings.Values(args[0])
+	}
+
+	return nil
+}
+
+func runSet(ctx *command.Context, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: set <name> <value>")
+	}
+
+	if err := settings.Set(args[0], args[1]); err != nil {
+		return err
+	}
+
+	ctx.Do(func() tea.Msg {
+		return settings.SavedMsg{}
+	})
+
+	return nil
+}
+
+// runExport writes to stdout in the shell when no file is given, in the
+// TUI the file defaults to pomogoro-<profile>.csv in the home folder.
+func runExport(ctx *command.Context, args []string) error {
+	if len(args) == 0 || len(args) > 2 || args[0] != "csv" {
+		return errors.New("usage: export csv [file]")
+	}
+
+	records, err := history.Read(profile.Current())
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 && ctx.Shell {
+		return history.WriteCSV(ctx.Out, records)
+	}
+
+	path := filepath.Join(homeDir(), fmt.Sprintf("pomogoro-%s.csv", profile.Current()))
+	if len(args) == 2 {
+		path = settings.ExpandPath(args[1])
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = history.WriteCSV(file, records)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(ctx.Out, i18n.T("Exported the history of %s to %s", profile.Current(), path))
+
+	return err
+}
+
+func homeDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+
+	return home
+}
@@ -2,14 +2,33 @@ package main
 
 import (
 	"embed"
+	"errors"
+	"flag"
 	"fmt"
 	"github.com/borissimkin/pomogoro/pkg/app"
+	"github.com/borissimkin/pomogoro/pkg/command"
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
 	"github.com/borissimkin/pomogoro/pkg/notification"
 	"github.com/borissimkin/pomogoro/pkg/pomodoro"
+	"github.com/borissimkin/pomogoro/pkg/profile"
+	"github.com/borissimkin/pomogoro/pkg/profiles"
 	"github.com/borissimkin/pomogoro/pkg/router"
 	"github.com/borissimkin/pomogoro/pkg/settings"
+	"github.com/borissimkin/pomogoro/pkg/stats"
+	"github.com/borissimkin/pomogoro/pkg/status"
+	"github.com/borissimkin/pomogoro/pkg/subscriber"
+	"github.com/borissimkin/pomogoro/pkg/terminal"
 	tea "github.com/charmbracelet/bubbletea"
 	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	busCloseTimeout  = 3 * time.Second
+	errorLogFilename = "errors.log"
 )
 
 //go:embed assets
@@ -18,18 +37,152 @@ var assets embed.FS
 func main() {
 	notification.Assets = assets
 
+	if len(os.Args) > 1 && os.Args[1] == "status" {
+		if err := status.Run(os.Args[2:], os.Stdout); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	defaultProfile := profile.Default
+	if name, ok := os.LookupEnv("POMOGORO_PROFILE"); ok {
+		defaultProfile = name
+	}
+
+	profileName := flag.String("profile", defaultProfile, "settings profile to use (env POMOGORO_PROFILE)")
+	create := flag.Bool("create", false, "create the profile when it does not exist")
+	inline := flag.Bool("inline", false, "show the timer on a single line among the output of the shell")
+	altScreen := flag.Bool("alt-screen", false, "use the whole terminal and give the screen back to the shell on exit")
+	settings.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if *inline && *altScreen {
+		fmt.Println("Error: --inline and --alt-screen can not be used together")
+		os.Exit(2)
+	}
+
+	if err := useProfile(*profileName, *create); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	// the language, keys and notifications would silently fall back to the
+	// defaults, the config has to be fixed first
+	s, err := settings.NewSettings()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	i18n.Set(s.Language)
+	keyboard.Set(s.KeyboardLayout)
+
+	if flag.NArg() > 0 {
+		runShellCommand(flag.Args())
+		return
+	}
+
+	soundPlayer := notification.NewSoundPlayer()
+	soundPlayer.InitSoundContext()
+
+	bus := event.NewBus()
+	bus.OnError = logError
+	subscriber.Notifications(bus, *s, soundPlayer)
+	subscriber.History(bus)
+
 	r := router.NewRouter()
+	mainPage := pomodoro.NewModel(&r, bus)
 
 	routes := []router.Route{
-		router.NewRoute(app.MainPageName, pomodoro.NewModel(&r)),
-		router.NewRoute(app.SettingsPageName, settings.NewModel(&r)),
+		router.NewRoute(app.MainPageName, mainPage),
+		router.NewLazyRoute(app.SettingsPageName, func() router.RouteValue { return settings.NewModel(&r) }),
+		router.NewLazyRoute(app.ProfilesPageName, func() router.RouteValue { return profiles.NewModel(&r) }),
+		router.NewLazyRoute(app.StatsPageName, func() router.RouteValue { return stats.NewModel(&r) }),
 	}
 
 	r.SetRoutes(routes)
 
-	p := tea.NewProgram(r.CurrentRoute().Value)
-	if _, err := p.Run(); err != nil {
+	commandHistory, _ := command.LoadHistory()
+	commands := command.NewRegistry(mainPage.Commands()...)
+	commands.Add(shellCommands()...)
+	commands.Add(tuiCommands(&r)...)
+	r.SetCommands(commands, commandHistory)
+
+	// sequences of the title and the progress share the output with frames
+	options := []tea.ProgramOption{tea.WithReportFocus(), tea.WithOutput(terminal.Stdout)}
+	if *altScreen {
+		options = append(options, tea.WithAltScreen())
+	}
+
+	r.SetInline(*inline)
+	mainPage.SetInline(*inline)
+
+	p := tea.NewProgram(&r, options...)
+
+	// the pages are told about session events too, e.g. for statistics
+	bus.Subscribe("pages", func(e event.Event) {
+		p.Send(e)
+	})
+
+	_, err = p.Run()
+	mainPage.RestoreTerminal()
+	bus.Close(busCloseTimeout)
+	_ = status.Remove()
+	if err != nil {
 		fmt.Println("Error starting program:", err)
 		os.Exit(1)
 	}
 }
+
+// useProfile makes name the current profile. A profile that does not exist
+// is only created when asked to, so a typo does not start an empty one.
+func useProfile(name string, create bool) error {
+	if err := profile.Validate(name); err != nil {
+		return err
+	}
+
+	if !profile.Exists(name) {
+		if !create {
+			return fmt.Errorf("%w: %s, use --create to create it", profile.ErrNotExists, name)
+		}
+
+		if err := profile.Create(name); err != nil {
+			return err
+		}
+	}
+
+	return profile.Set(name)
+}
+
+// logError appends err to the error log in the pomogoro cache folder, it
+// is used for errors of event subscribers that happen in the background.
+func logError(err error) {
+	path, _ := os.UserCacheDir()
+	path = filepath.Join(path, "pomogoro")
+
+	if os.MkdirAll(path, 0700) != nil {
+		return
+	}
+
+	file, openErr := os.OpenFile(filepath.Join(path, errorLogFilename), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if openErr != nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(file, "%s %v\n", time.Now().Format(time.RFC3339), err)
+	_ = file.Close()
+}
+
+func runShellCommand(args []string) {
+	commands := command.NewRegistry(shellCommands()...)
+
+	err := commands.RunArgs(&command.Context{Out: os.Stdout, Shell: true}, args)
+	if errors.Is(err, command.ErrUnknownCommand) {
+		fmt.Printf("Error: %v, commands are:\n  status — %s\n%s\n", err, i18n.T("print the state of the running timer"), commands.Usage())
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+}
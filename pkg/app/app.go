@@ -3,4 +3,6 @@ package app
 const (
 	MainPageName     = "pomodoro"
 	SettingsPageName = "settings"
+	ProfilesPageName = "profiles"
+	StatsPageName    = "stats"
 )
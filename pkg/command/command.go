@@ -0,0 +1,157 @@
+// Package command runs commands typed on the command line of the TUI, such
+// as `set work 50m`. The commands that do not need the TUI are also run from
+// the shell as `pomogoro set work 50m`.
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+	"io"
+	"strings"
+)
+
+var ErrUnknownCommand = errors.New("unknown command")
+
+// Command is an action with arguments, Complete lists the candidates for
+// the last of args.
+type Command struct {
+	Name        string
+	Usage       string
+	Description string
+	Complete    func(args []string) []string
+	Run         func(ctx *Context, args []string) error
+}
+
+// Context is where a command runs. Out is the terminal in the shell, in the
+// TUI what is written to it is shown after the command.
+type Context struct {
+	Out   io.Writer
+	Shell bool
+	cmds  []tea.Cmd
+}
+
+// Do runs cmd in the TUI after the command, it is ignored in the shell.
+func (c *Context) Do(cmd tea.Cmd) {
+	c.cmds = append(c.cmds, cmd)
+}
+
+func (c *Context) Cmd() tea.Cmd {
+	return tea.Batch(c.cmds...)
+}
+
+// Registry holds commands in the order they are suggested.
+type Registry struct {
+	commands []Command
+}
+
+func NewRegistry(commands ...Command) *Registry {
+	r := &Registry{}
+	r.Add(commands...)
+
+	return r
+}
+
+func (r *Registry) Add(commands ...Command) {
+	r.commands = append(r.commands, commands...)
+}
+
+func (r *Registry) Find(name string) (Command, error) {
+	for _, command := range r.commands {
+		if command.Name == name {
+			return command, nil
+		}
+	}
+
+	return Command{}, fmt.Errorf("%w: %s", ErrUnknownCommand, name)
+}
+
+// Run runs a command line, e.g. "set work 50m".
+func (r *Registry) Run(ctx *Context, line string) error {
+	return r.RunArgs(ctx, strings.Fields(line))
+}
+
+// RunArgs runs the command named by the first of args.
+func (r *Registry) RunArgs(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	command, err := r.Find(args[0])
+	if err != nil {
+		return err
+	}
+
+	return command.Run(ctx, args[1:])
+}
+
+// Suggestion is a command line that completes the typed one.
+type Suggestion struct {
+	Line        string
+	Description string
+}
+
+// Complete suggests the command names matching the typed line, or after a
+// command name the candidates for its last argument. Matching is fuzzy.
+func (r *Registry) Complete(line string) []Suggestion {
+	words := strings.Fields(line)
+	if strings.HasSuffix(line, " ") || len(words) == 0 {
+		words = append(words, "")
+	}
+
+	if len(words) == 1 {
+		names := make([]string, len(r.commands))
+		for index, command := range r.commands {
+			names[index] = command.Name
+		}
+
+		var suggestions []Suggestion
+		for _, index := range Fuzzy(words[0], names) {
+			command := r.commands[index]
+			suggestions = append(suggestions, Suggestion{Line: command.Name + " ", Description: command.usage()})
+		}
+
+		return suggestions
+	}
+
+	command, err := r.Find(words[0])
+	if err != nil || command.Complete == nil {
+		return nil
+	}
+
+	args := words[1:]
+	candidates := command.Complete(args)
+	prefix := strings.Join(words[:len(words)-1], " ") + " "
+
+	var suggestions []Suggestion
+	for _, index := range Fuzzy(args[len(args)-1], candidates) {
+		line := prefix + candidates[index]
+		suggestions = append(suggestions, Suggestion{Line: line + " ", Description: line})
+	}
+
+	return suggestions
+}
+
+func (c Command) usage() string {
+	usage := c.Usage
+	if usage == "" {
+		usage = c.Name
+	}
+
+	if c.Description == "" {
+		return usage
+	}
+
+	return fmt.Sprintf("%s — %s", usage, i18n.T(c.Description))
+}
+
+// Usage lists the commands with their descriptions, one per line.
+func (r *Registry) Usage() string {
+	lines := make([]string, len(r.commands))
+	for index, command := range r.commands {
+		lines[index] = "  " + command.usage()
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,58 @@
+package command
+
+import (
+	"sort"
+	"strings"
+)
+
+// Fuzzy returns the indexes of the candidates that contain the letters of
+// query in order, best matches first: prefixes, then the ones whose
+// letters are closest together.
+func Fuzzy(query string, candidates []string) []int {
+	type match struct {
+		index int
+		score int
+	}
+
+	var matches []match
+
+	for index, candidate := range candidates {
+		if score, ok := fuzzyScore(strings.ToLower(query), strings.ToLower(candidate)); ok {
+			matches = append(matches, match{index: index, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score < matches[j].score
+	})
+
+	indexes := make([]int, len(matches))
+	for i, m := range matches {
+		indexes[i] = m.index
+	}
+
+	return indexes
+}
+
+// fuzzyScore is lower for better matches, it is the number of letters
+// skipped in candidate with a penalty when it does not start with query.
+func fuzzyScore(query, candidate string) (int, bool) {
+	if strings.HasPrefix(candidate, query) {
+		return 0, true
+	}
+
+	score := len(candidate)
+	position := 0
+
+	for _, letter := range query {
+		found := strings.IndexRune(candidate[position:], letter)
+		if found < 0 {
+			return 0, false
+		}
+
+		score += found
+		position += found + len(string(letter))
+	}
+
+	return score, true
+}
@@ -0,0 +1,36 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzy(t *testing.T) {
+	candidates := []string{"start", "stop", "set", "export", "settings", "reset", "goto"}
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{query: "", want: []int{0, 1, 2, 3, 4, 5, 6}},
+		// prefixes keep their order and come first
+		{query: "s", want: []int{0, 1, 2, 4, 5}},
+		{query: "set", want: []int{2, 4, 5}},
+		{query: "sg", want: []int{4}},
+		// shorter candidates with fewer skipped letters come first
+		{query: "et", want: []int{2, 5, 4, 3}},
+		{query: "GO", want: []int{6}},
+		{query: "xyz", want: []int{}},
+	}
+
+	for _, test := range tests {
+		got := Fuzzy(test.query, candidates)
+		if len(got) == 0 && len(test.want) == 0 {
+			continue
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Fuzzy(%q) = %v, want %v", test.query, got, test.want)
+		}
+	}
+}
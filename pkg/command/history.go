@@ -0,0 +1,88 @@
+package command
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	folder          = "pomogoro"
+	historyFilename = "commands"
+	historyLimit    = 100
+)
+
+// History is the list of entered command lines, it is kept in the cache
+// folder so it is shared by the profiles.
+type History struct {
+	lines []string
+}
+
+func getHistoryPath() string {
+	path, _ := os.UserCacheDir()
+
+	return filepath.Join(path, folder, historyFilename)
+}
+
+// LoadHistory reads the history, it is empty when there is none yet.
+func LoadHistory() (*History, error) {
+	h := &History{}
+
+	file, err := os.Open(getHistoryPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			h.lines = append(h.lines, line)
+		}
+	}
+
+	return h, scanner.Err()
+}
+
+// Lines returns the entered lines, the latest last.
+func (h *History) Lines() []string {
+	return h.lines
+}
+
+// Add puts line at the end of the history, an earlier copy of it is
+// removed, and saves the history.
+func (h *History) Add(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+
+	lines := make([]string, 0, len(h.lines)+1)
+	for _, entered := range h.lines {
+		if entered != line {
+			lines = append(lines, entered)
+		}
+	}
+
+	h.lines = append(lines, line)
+	if len(h.lines) > historyLimit {
+		h.lines = h.lines[len(h.lines)-historyLimit:]
+	}
+
+	return h.save()
+}
+
+func (h *History) save() error {
+	path := getHistoryPath()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(strings.Join(h.lines, "\n")+"\n"), 0600)
+}
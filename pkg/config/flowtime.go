@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+type Mode string
+
+const (
+	// ClassicMode counts every session down from its duration.
+	ClassicMode Mode = "classic"
+	// FlowtimeMode counts work up until the user ends it, the break that
+	// follows is computed from the worked time.
+	FlowtimeMode Mode = "flowtime"
+)
+
+// Bracket gives Break after work of less than UpTo, a zero UpTo matches
+// any worked time.
+type Bracket struct {
+	UpTo  time.Duration
+	Break time.Duration
+}
+
+type Flowtime struct {
+	// BreakRatio is the break per worked time, e.g. 0.2 is 1 minute of
+	// break for 5 minutes of work. Brackets are used when it is 0.
+	BreakRatio float64
+	Brackets   []Bracket
+}
+
+func defaultFlowtime() Flowtime {
+	return Flowtime{
+		Brackets: []Bracket{
+			{UpTo: 25 * time.Minute, Break: 5 * time.Minute},
+			{UpTo: 50 * time.Minute, Break: 8 * time.Minute},
+			{UpTo: 90 * time.Minute, Break: 10 * time.Minute},
+			{Break: 15 * time.Minute},
+		},
+	}
+}
+
+// BreakFor returns the break earned by worked time.
+func (f Flowtime) BreakFor(worked time.Duration) time.Duration {
+	if f.BreakRatio > 0 {
+		return time.Duration(float64(worked) * f.BreakRatio).Round(time.Second)
+	}
+
+	for _, bracket := range f.Brackets {
+		if bracket.UpTo == 0 || worked < bracket.UpTo {
+			return bracket.Break
+		}
+	}
+
+	return 0
+}
+
+func (f Flowtime) validate() []error {
+	var errs []error
+
+	if f.BreakRatio < 0 {
+		errs = append(errs, fmt.Errorf("flowtime break ratio must not be negative, got %v", f.BreakRatio))
+	}
+
+	if f.BreakRatio == 0 && len(f.Brackets) == 0 {
+		errs = append(errs, fmt.Errorf("flowtime needs a break ratio or brackets"))
+	}
+
+	var previous time.Duration
+
+	for index, bracket := range f.Brackets {
+		if bracket.UpTo == 0 && index != len(f.Brackets)-1 {
+			errs = append(errs, fmt.Errorf("flowtime bracket %v without up_to must be the last one", index+1))
+		}
+
+		if bracket.UpTo != 0 && bracket.UpTo <= previous {
+			errs = append(errs, fmt.Errorf("flowtime bracket %v: up_to must be greater than %v", index+1, previous))
+		}
+
+		if bracket.Break <= 0 {
+			errs = append(errs, fmt.Errorf("flowtime bracket %v: break must be positive", index+1))
+		}
+
+		previous = bracket.UpTo
+	}
+
+	return errs
+}
@@ -0,0 +1,213 @@
+// Package config holds the settings of the timer and checks them, it has
+// no dependency on a UI or on how the settings are stored.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard/layout"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"regexp"
+	"time"
+)
+
+// CurrentVersion is the version of the settings schema written to the
+// config file. Versions 1 and 2 are the legacy settings.json, files without
+// a version field are version 1.
+const CurrentVersion = 4
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Durations are the lengths of the sessions.
+type Durations map[session.Type]time.Duration
+
+// Notification is announced when a session ends. Empty messages and sound
+// file mean the built-in ones, Tone is the built-in sound.
+type Notification struct {
+	Sound     bool
+	Push      bool
+	Tone      Tone
+	SoundFile string
+	Messages  Messages
+}
+
+// Messages are the texts of push notifications of each session.
+type Messages map[session.Type]string
+
+// Colors replace the colors of session tabs and progress bars, empty
+// means the color of the session.
+type Colors map[session.Type]string
+
+type AutoStart map[session.Type]bool
+
+// Overtime keeps the clock running past the end of a session, the next
+// session starts on keypress.
+type Overtime map[session.Type]bool
+
+type Settings struct {
+	Version                    int
+	WorkSessionsUntilLongBreak int
+	CountSkipped               bool
+	Durations                  Durations
+	ShowProgressBar            bool
+	Mouse                      bool
+	Notification               Notification
+	AutoStart                  AutoStart
+	Overtime                   Overtime
+	Colors                     Colors
+	Theme                      Theme
+	Mode                       Mode
+	Flowtime                   Flowtime
+	// Language of the UI, i18n.Auto follows the locale.
+	Language i18n.Language
+	// KeyboardLayout is mapped to QWERTY before keys are matched.
+	KeyboardLayout layout.Layout
+	// TerminalTitle shows the time in the title of the terminal window,
+	// TerminalProgress in its taskbar button or tab.
+	TerminalTitle    bool
+	TerminalProgress bool
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		Version:                    CurrentVersion,
+		WorkSessionsUntilLongBreak: 4,
+		ShowProgressBar:            true,
+		Mouse:                      true,
+		TerminalTitle:              true,
+		Notification: Notification{
+			Sound: true,
+			Push:  true,
+			Tone:  RingTone,
+			Messages: Messages{
+				session.Work:      "",
+				session.Break:     "",
+				session.LongBreak: "",
+			},
+		},
+		AutoStart: AutoStart{
+			session.Work:      true,
+			session.Break:     true,
+			session.LongBreak: true,
+		},
+		Durations: Durations{
+			session.Work:      time.Minute * 25,
+			session.Break:     time.Minute * 5,
+			session.LongBreak: time.Minute * 15,
+		},
+		Overtime: Overtime{
+			session.Work:      false,
+			session.Break:     false,
+			session.LongBreak: false,
+		},
+		Colors: Colors{
+			session.Work:      "",
+			session.Break:     "",
+			session.LongBreak: "",
+		},
+		Theme:    TomatoTheme,
+		Mode:     ClassicMode,
+		Flowtime: defaultFlowtime(),
+	}
+}
+
+// Clone returns a copy of s that shares no maps or slices with it.
+func (s *Settings) Clone() Settings {
+	c := *s
+
+	c.Durations = make(Durations, len(s.Durations))
+	for key, value := range s.Durations {
+		c.Durations[key] = value
+	}
+
+	c.AutoStart = make(AutoStart, len(s.AutoStart))
+	for key, value := range s.AutoStart {
+		c.AutoStart[key] = value
+	}
+
+	c.Overtime = make(Overtime, len(s.Overtime))
+	for key, value := range s.Overtime {
+		c.Overtime[key] = value
+	}
+
+	c.Notification.Messages = make(Messages, len(s.Notification.Messages))
+	for key, value := range s.Notification.Messages {
+		c.Notification.Messages[key] = value
+	}
+
+	c.Colors = make(Colors, len(s.Colors))
+	for key, value := range s.Colors {
+		c.Colors[key] = value
+	}
+
+	c.Flowtime.Brackets = append([]Bracket(nil), s.Flowtime.Brackets...)
+
+	return c
+}
+
+// Validate reports every invalid field, messages are shown in the UI.
+func (s *Settings) Validate() error {
+	var errs []error
+
+	for _, sessionType := range session.Types() {
+		duration, ok := s.Durations[sessionType]
+		if !ok {
+			errs = append(errs, fmt.Errorf("duration of %s is missing", sessionType.Name()))
+			continue
+		}
+
+		if duration < time.Minute {
+			errs = append(errs, fmt.Errorf("duration of %s must be at least 1m, got %v", sessionType.Name(), duration))
+		}
+	}
+
+	for _, sessionType := range session.Types() {
+		if color := s.Colors[sessionType]; color != "" && !IsColor(color) {
+			errs = append(errs, fmt.Errorf("color of %s must look like #ba4949, got %q", sessionType.Name(), color))
+		}
+	}
+
+	if !IsTheme(s.Theme) {
+		errs = append(errs, fmt.Errorf("theme must be one of %v, got %q", Themes, s.Theme))
+	}
+
+	if !IsTone(s.Notification.Tone) {
+		errs = append(errs, fmt.Errorf("sound must be one of %v, got %q", Tones, s.Notification.Tone))
+	}
+
+	if s.WorkSessionsUntilLongBreak < 0 {
+		errs = append(errs, fmt.Errorf("long break interval must not be negative, got %v", s.WorkSessionsUntilLongBreak))
+	}
+
+	switch s.Mode {
+	case ClassicMode:
+	case FlowtimeMode:
+		errs = append(errs, s.Flowtime.validate()...)
+	default:
+		errs = append(errs, fmt.Errorf("mode must be %q or %q, got %q", ClassicMode, FlowtimeMode, s.Mode))
+	}
+
+	if !i18n.IsLanguage(s.Language) {
+		errs = append(errs, fmt.Errorf("language must be one of %v or empty for the locale, got %q", i18n.Languages, s.Language))
+	}
+
+	if !layout.IsLayout(s.KeyboardLayout) {
+		errs = append(errs, fmt.Errorf("keyboard layout must be one of %v or empty, got %q", layout.Layouts, s.KeyboardLayout))
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsColor reports whether color is a hex color like #ba4949.
+func IsColor(color string) bool {
+	return colorPattern.MatchString(color)
+}
+
+func (s *Settings) IsFlowtime() bool {
+	return s.Mode == FlowtimeMode
+}
+
+func (s *Settings) GetDuration(sessionType session.Type) time.Duration {
+	return s.Durations[sessionType]
+}
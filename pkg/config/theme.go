@@ -0,0 +1,75 @@
+package config
+
+import "github.com/borissimkin/pomogoro/pkg/session"
+
+// Theme is a palette of the session colors, Colors replace single colors
+// of it.
+type Theme string
+
+const (
+	// TomatoTheme keeps the colors of the sessions.
+	TomatoTheme Theme = "tomato"
+	OceanTheme  Theme = "ocean"
+	ForestTheme Theme = "forest"
+	MonoTheme   Theme = "mono"
+)
+
+// Themes are offered in this order.
+var Themes = []Theme{TomatoTheme, OceanTheme, ForestTheme, MonoTheme}
+
+var themeColors = map[Theme]Colors{
+	OceanTheme: {
+		session.Work:      "#1d5c8f",
+		session.Break:     "#2a9d8f",
+		session.LongBreak: "#264653",
+	},
+	ForestTheme: {
+		session.Work:      "#3a5a40",
+		session.Break:     "#588157",
+		session.LongBreak: "#6b705c",
+	},
+	MonoTheme: {
+		session.Work:      "#5c5c5c",
+		session.Break:     "#7a7a7a",
+		session.LongBreak: "#3d3d3d",
+	},
+}
+
+func IsTheme(t Theme) bool {
+	for _, theme := range Themes {
+		if theme == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Color is the color of the session in the theme, empty for the color of
+// the session itself.
+func (t Theme) Color(sessionType session.Type) string {
+	return themeColors[t][sessionType]
+}
+
+// Tone is the built-in sound of the notification, a sound file replaces
+// it.
+type Tone string
+
+const (
+	RingTone Tone = "ring"
+	BellTone Tone = "bell"
+	BeepTone Tone = "beep"
+)
+
+// Tones are offered in this order.
+var Tones = []Tone{RingTone, BellTone, BeepTone}
+
+func IsTone(t Tone) bool {
+	for _, tone := range Tones {
+		if tone == t {
+			return true
+		}
+	}
+
+	return false
+}
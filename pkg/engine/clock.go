@@ -0,0 +1,17 @@
+package engine
+
+import "time"
+
+// Clock is the source of time of an Engine, tests and simulations can
+// provide their own.
+type Clock interface {
+	Now() time.Time
+}
+
+// WallClock reads the system time without the monotonic reading: the
+// monotonic clock stops while the system sleeps and a deadline must not.
+type WallClock struct{}
+
+func (WallClock) Now() time.Time {
+	return time.Now().Round(0)
+}
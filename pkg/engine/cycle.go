@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/session"
+)
+
+// TotalWorkSessions is the number of work sessions that ran out.
+func (e *Engine) TotalWorkSessions() int {
+	return e.state.Completed[session.Work]
+}
+
+// SkippedWorkSessions is the number of work sessions ended early.
+func (e *Engine) SkippedWorkSessions() int {
+	return e.state.Skipped[session.Work]
+}
+
+// AbandonedWorkSessions is the number of started work sessions that were
+// reset or switched away from.
+func (e *Engine) AbandonedWorkSessions() int {
+	return e.state.Abandoned[session.Work]
+}
+
+// SessionsBeforeLongBreak is the number of work sessions left in the cycle.
+func (e *Engine) SessionsBeforeLongBreak() int {
+	interval := e.settings.WorkSessionsUntilLongBreak
+	if interval <= 0 {
+		return 0
+	}
+
+	return interval - e.state.Cycle%interval
+}
+
+// CompletedInCycle is the number of work sessions counted since the last
+// long break.
+func (e *Engine) CompletedInCycle() int {
+	interval := e.settings.WorkSessionsUntilLongBreak
+	if interval <= 0 {
+		return e.state.Cycle
+	}
+
+	return e.state.Cycle % interval
+}
+
+// NextSessionType is the session that follows the current one when it
+// runs out.
+func (e *Engine) NextSessionType() session.Type {
+	return e.nextSessionType(true)
+}
+
+// nextSessionType is the session that follows the current one, counted
+// tells whether the current session counts toward the long break.
+func (e *Engine) nextSessionType(counted bool) session.Type {
+	if e.state.SessionType != session.Work {
+		return session.Work
+	}
+
+	if e.settings.IsFlowtime() {
+		return session.Break
+	}
+
+	interval := e.settings.WorkSessionsUntilLongBreak
+	if interval <= 0 || !counted {
+		return session.Break
+	}
+
+	if (e.state.Cycle+1)%interval == 0 {
+		return session.LongBreak
+	}
+
+	return session.Break
+}
+
+// nextSession moves on in the cycle, counted tells whether the current
+// session counts toward the long break.
+func (e *Engine) nextSession(counted bool) {
+	next := e.nextSessionType(counted)
+
+	if counted && e.state.SessionType == session.Work {
+		e.state.Cycle++
+	}
+
+	e.state.PreviousSessionType = e.state.SessionType
+	e.state.SessionType = next
+	e.state.Started = false
+
+	e.setDuration(e.durationOf(next))
+}
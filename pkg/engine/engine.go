@@ -0,0 +1,478 @@
+// Package engine runs the pomodoro cycle: work sessions alternate with
+// breaks and every few work sessions the break is a long one. It has no
+// dependency on a UI, the TUI, the CLI and other programs drive it through
+// its methods and observe it with events published on an event.Bus.
+//
+// The end of a running session is kept as a deadline of the Clock, so the
+// remaining time stays correct however rarely Tick is called.
+package engine
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"time"
+)
+
+// Callbacks are called synchronously after the state has changed, nil
+// callbacks are skipped. Side effects belong to event subscribers, callbacks
+// are meant for redrawing a UI.
+type Callbacks struct {
+	// OnChange is called after every change of the state.
+	OnChange func(state State)
+}
+
+type Option func(e *Engine)
+
+func WithClock(clock Clock) Option {
+	return func(e *Engine) {
+		e.clock = clock
+	}
+}
+
+func WithCallbacks(callbacks Callbacks) Option {
+	return func(e *Engine) {
+		e.callbacks = callbacks
+	}
+}
+
+// WithBus publishes the events of the engine on bus.
+func WithBus(bus *event.Bus) Option {
+	return func(e *Engine) {
+		e.bus = bus
+	}
+}
+
+// WithProfile names the profile the sessions are counted in, it is read
+// when a session completes so a switch of the profile takes effect at once.
+func WithProfile(current func() string) Option {
+	return func(e *Engine) {
+		e.profile = current
+	}
+}
+
+type Engine struct {
+	settings  *config.Settings
+	clock     Clock
+	callbacks Callbacks
+	bus       *event.Bus
+	profile   func() string
+	state     State
+}
+
+// New returns an engine at the start of a paused work session.
+func New(s *config.Settings, options ...Option) *Engine {
+	e := &Engine{
+		settings: s,
+		clock:    WallClock{},
+		profile:  func() string { return "" },
+		state: State{
+			SessionType: session.Work,
+			Completed:   make(map[session.Type]int),
+			Skipped:     make(map[session.Type]int),
+			Abandoned:   make(map[session.Type]int),
+		},
+	}
+
+	for _, option := range options {
+		option(e)
+	}
+
+	e.setDuration(e.durationOf(e.state.SessionType))
+
+	return e
+}
+
+// Settings are the settings the engine runs with, see SetSettings.
+func (e *Engine) Settings() *config.Settings {
+	return e.settings
+}
+
+// SetSettings replaces the settings and reports whether the duration of the
+// current session has changed, the timer is left as it is.
+func (e *Engine) SetSettings(s *config.Settings) bool {
+	previous := e.durationOf(e.state.SessionType)
+	wasCountingUp := e.CountingUp()
+	e.settings = s
+
+	e.bus.Publish(event.SettingsChanged{At: e.clock.Now(), Settings: *s})
+
+	return previous != e.durationOf(e.state.SessionType) || wasCountingUp != e.CountingUp()
+}
+
+// Now is the time of the clock of the engine.
+func (e *Engine) Now() time.Time {
+	return e.clock.Now()
+}
+
+// State returns a copy of the current state.
+func (e *Engine) State() State {
+	return e.state.clone()
+}
+
+// Restore continues from a state returned by State. A running session
+// whose deadline has passed completes on the next Tick.
+func (e *Engine) Restore(state State) {
+	e.state = state.clone()
+	e.changed()
+}
+
+// SessionType is the type of the current session.
+func (e *Engine) SessionType() session.Type {
+	return e.state.SessionType
+}
+
+// Session is the current session as shown, see SessionOf.
+func (e *Engine) Session() *session.Session {
+	return e.SessionOf(e.state.SessionType)
+}
+
+// SessionOf returns the session of the current mode with the colors of
+// the settings.
+func (e *Engine) SessionOf(sessionType session.Type) *session.Session {
+	var s session.Session
+
+	switch sessionType {
+	case session.Work:
+		s = session.WorkSession
+		if e.settings.IsFlowtime() {
+			s = session.FlowtimeSession
+		}
+	case session.Break:
+		s = session.BreakSession
+	case session.LongBreak:
+		s = session.LongBreakSession
+	default:
+		return nil
+	}
+
+	if color := e.settings.Theme.Color(sessionType); color != "" {
+		s.BackgroundColor = color
+	}
+
+	if color := e.settings.Colors[sessionType]; color != "" {
+		s.BackgroundColor = color
+	}
+
+	return &s
+}
+
+// Sessions returns the sessions of the current mode ordered by type.
+func (e *Engine) Sessions() []*session.Session {
+	types := e.sessionTypes()
+	sessions := make([]*session.Session, 0, len(types))
+
+	for _, sessionType := range types {
+		sessions = append(sessions, e.SessionOf(sessionType))
+	}
+
+	return sessions
+}
+
+// Running reports whether the clock of the session is running.
+func (e *Engine) Running() bool {
+	return e.state.Running
+}
+
+// Remaining is the time left in the session, it is negative when the
+// deadline has passed and Tick has not been called yet.
+func (e *Engine) Remaining() time.Duration {
+	if e.state.Running {
+		return e.state.Deadline.Sub(e.clock.Now())
+	}
+
+	return e.state.Remaining
+}
+
+// Deadline is the end of the running session, zero when paused.
+func (e *Engine) Deadline() time.Time {
+	if !e.state.Running {
+		return time.Time{}
+	}
+
+	return e.state.Deadline
+}
+
+// Duration is the length of the current session including adjustments.
+func (e *Engine) Duration() time.Duration {
+	return e.state.Duration
+}
+
+// Progress is the elapsed part of the session from 0 to 1, always 0 when
+// counting up.
+func (e *Engine) Progress() float64 {
+	if e.state.Duration <= 0 {
+		return 0
+	}
+
+	return float64(e.state.Duration-max(e.Remaining(), 0)) / float64(e.state.Duration)
+}
+
+// Start runs the clock of the session, the session is announced as
+// started the first time and as resumed afterwards.
+func (e *Engine) Start() {
+	if e.state.Running {
+		return
+	}
+
+	e.state.Deadline = e.clock.Now().Add(e.state.Remaining)
+	e.state.Running = true
+	e.changed()
+
+	if e.state.Started {
+		e.bus.Publish(event.Resumed{At: e.clock.Now(), SessionType: e.state.SessionType, Remaining: e.state.Remaining})
+	} else {
+		e.started()
+	}
+}
+
+// Stop pauses the clock of the session and keeps the remaining time.
+func (e *Engine) Stop() {
+	if !e.state.Running {
+		return
+	}
+
+	e.pause()
+	e.changed()
+
+	e.bus.Publish(event.Paused{At: e.clock.Now(), SessionType: e.state.SessionType, Remaining: e.state.Remaining})
+}
+
+// Toggle stops a running session and starts a paused one.
+func (e *Engine) Toggle() {
+	if e.state.Running {
+		e.Stop()
+	} else {
+		e.Start()
+	}
+}
+
+// Tick completes the session when its deadline has passed and reports
+// whether it did. The next session starts if it is set to auto start, a
+// session with overtime goes on counting instead.
+func (e *Engine) Tick() bool {
+	if !e.state.Running || e.Remaining() > 0 || e.CountingUp() {
+		return false
+	}
+
+	if e.OvertimeEnabled() {
+		e.startOvertime()
+		return false
+	}
+
+	e.complete(e.state.Duration, e.Overtime())
+
+	return true
+}
+
+// complete finishes the current session as done after duration and
+// starts the next one if it is set to auto start.
+func (e *Engine) complete(duration, overtime time.Duration) {
+	finished := e.state.SessionType
+	notified := e.state.Overtime
+
+	if e.CountingUp() {
+		e.state.EarnedBreak = e.settings.Flowtime.BreakFor(duration)
+	}
+
+	e.state.Completed[finished]++
+	e.nextSession(true)
+
+	e.bus.Publish(event.Completed{
+		At:               e.clock.Now(),
+		Profile:          e.profile(),
+		SessionType:      finished,
+		Duration:         duration,
+		Overtime:         overtime,
+		Next:             e.state.SessionType,
+		NotifiedOvertime: notified,
+	})
+
+	if e.settings.AutoStart[e.state.SessionType] {
+		e.started()
+	} else {
+		e.pause()
+	}
+
+	e.changed()
+}
+
+// Reset restarts the current session with its full duration.
+func (e *Engine) Reset() {
+	e.abandon()
+	e.Restart()
+}
+
+// Restart starts the current session over with its full duration without
+// counting it as abandoned, e.g. after its duration has been changed in
+// the settings.
+func (e *Engine) Restart() {
+	e.setDuration(e.durationOf(e.state.SessionType))
+	e.state.Started = e.state.Running
+	e.changed()
+
+	e.bus.Publish(event.Reset{At: e.clock.Now(), SessionType: e.state.SessionType, Duration: e.state.Duration})
+}
+
+// NextCompletes reports whether Next completes the current session rather
+// than skipping it. Flowtime work has no end of its own and a session in
+// overtime has already run out, so they are completed, but flowtime work
+// that has not counted a second yet is no work to record.
+func (e *Engine) NextCompletes() bool {
+	return e.state.Overtime || e.CountingUp() && e.Elapsed() >= time.Second
+}
+
+// Next ends the current session and moves to the next one in the cycle,
+// see NextCompletes.
+func (e *Engine) Next() {
+	if e.CountingUp() && e.NextCompletes() {
+		e.complete(e.Elapsed(), 0)
+		return
+	}
+
+	if e.state.Overtime {
+		e.complete(e.state.Duration, e.Overtime())
+		return
+	}
+
+	skipped := e.state.SessionType
+	elapsed := e.state.Duration - e.Remaining()
+
+	e.state.Skipped[skipped]++
+	e.nextSession(e.settings.CountSkipped)
+	e.changed()
+
+	e.bus.Publish(event.Skipped{
+		At:          e.clock.Now(),
+		SessionType: skipped,
+		Elapsed:     elapsed,
+		Next:        e.state.SessionType,
+	})
+
+	if e.state.Running {
+		e.started()
+	}
+}
+
+// SetSession switches to a session, a started current one is counted as
+// abandoned.
+func (e *Engine) SetSession(sessionType session.Type) {
+	e.abandon()
+	e.state.SessionType = sessionType
+	e.Restart()
+}
+
+// abandon counts the current session as abandoned when some of it has
+// passed.
+func (e *Engine) abandon() {
+	if e.state.Started && e.Elapsed() > 0 {
+		e.state.Abandoned[e.state.SessionType]++
+	}
+}
+
+// Move switches to the session delta positions away in the tab order,
+// wrapping around at the ends.
+func (e *Engine) Move(delta int) {
+	types := e.sessionTypes()
+	index := 0
+
+	for i, sessionType := range types {
+		if sessionType == e.state.SessionType {
+			index = i
+		}
+	}
+
+	index = ((index+delta)%len(types) + len(types)) % len(types)
+
+	e.SetSession(types[index])
+}
+
+// Adjust adds delta to the remaining time and the session duration, a
+// session pushed below zero ends and the next one starts. When counting
+// up or in overtime delta is added to the time counted instead.
+func (e *Engine) Adjust(delta time.Duration) {
+	if e.CountingUp() || e.state.Overtime {
+		if e.state.Overtime {
+			e.adjustOvertime(delta)
+		} else {
+			e.adjustElapsed(delta)
+		}
+
+		e.bus.Publish(event.TimeAdjusted{At: e.clock.Now(), SessionType: e.state.SessionType, Delta: delta, Remaining: e.Remaining()})
+		return
+	}
+
+	remaining := e.Remaining() + delta
+	if remaining < 0 {
+		e.Next()
+		return
+	}
+
+	e.state.Duration += delta
+	e.setRemaining(remaining)
+	e.changed()
+
+	e.bus.Publish(event.TimeAdjusted{At: e.clock.Now(), SessionType: e.state.SessionType, Delta: delta, Remaining: remaining})
+}
+
+// SetRemaining sets the time left of a session counting down, its
+// duration changes by the same amount. A session in overtime counts down
+// again.
+func (e *Engine) SetRemaining(remaining time.Duration) {
+	if e.CountingUp() || remaining <= 0 {
+		return
+	}
+
+	delta := remaining - e.Remaining()
+
+	e.state.Duration += delta
+	e.state.Overtime = false
+	e.setRemaining(remaining)
+	e.changed()
+
+	e.bus.Publish(event.TimeAdjusted{At: e.clock.Now(), SessionType: e.state.SessionType, Delta: delta, Remaining: remaining})
+}
+
+// Extend moves the deadline by d without changing the session duration,
+// e.g. to not count time away from the computer. A session in overtime
+// counts down again when the deadline moves past now.
+func (e *Engine) Extend(d time.Duration) {
+	remaining := e.Remaining() + d
+
+	e.setRemaining(remaining)
+	if remaining > 0 {
+		e.state.Overtime = false
+	}
+	e.changed()
+
+	e.bus.Publish(event.TimeAdjusted{At: e.clock.Now(), SessionType: e.state.SessionType, Delta: d, Remaining: remaining})
+}
+
+func (e *Engine) setDuration(d time.Duration) {
+	e.state.Duration = d
+	e.state.Overtime = false
+	e.setRemaining(d)
+}
+
+func (e *Engine) setRemaining(d time.Duration) {
+	e.state.Remaining = d
+	e.state.Deadline = e.clock.Now().Add(d)
+}
+
+// started marks the current session as started and announces it.
+func (e *Engine) started() {
+	e.state.Started = true
+
+	e.bus.Publish(event.SessionStarted{At: e.clock.Now(), SessionType: e.state.SessionType, Duration: e.state.Duration})
+}
+
+func (e *Engine) pause() {
+	e.state.Remaining = e.Remaining()
+	e.state.Running = false
+}
+
+func (e *Engine) changed() {
+	if e.callbacks.OnChange != nil {
+		e.callbacks.OnChange(e.State())
+	}
+}
@@ -0,0 +1,254 @@
+package engine
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestEngine(t *testing.T, change func(s *config.Settings)) (*Engine, *fakeClock) {
+	t.Helper()
+
+	s := config.DefaultSettings()
+	if change != nil {
+		change(&s)
+	}
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)}
+
+	return New(&s, WithClock(clock)), clock
+}
+
+// runOut lets the current session run to its end.
+func runOut(e *Engine, clock *fakeClock) {
+	clock.advance(e.Remaining())
+	e.Tick()
+}
+
+func TestCycle(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval int
+		want     []session.Type
+	}{
+		{
+			name:     "long break every 4",
+			interval: 4,
+			want: []session.Type{
+				session.Break, session.Work, session.Break, session.Work, session.Break,
+				session.Work, session.LongBreak, session.Work, session.Break,
+			},
+		},
+		{
+			name:     "long break every 2",
+			interval: 2,
+			want:     []session.Type{session.Break, session.Work, session.LongBreak, session.Work, session.Break},
+		},
+		{
+			name:     "no long breaks",
+			interval: 0,
+			want:     []session.Type{session.Break, session.Work, session.Break, session.Work, session.Break},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e, clock := newTestEngine(t, func(s *config.Settings) {
+				s.WorkSessionsUntilLongBreak = test.interval
+			})
+			e.Start()
+
+			for index, want := range test.want {
+				runOut(e, clock)
+
+				if got := e.SessionType(); got != want {
+					t.Fatalf("session %d is %v, want %v", index+1, got, want)
+				}
+				if !e.Running() {
+					t.Fatalf("session %d did not auto start", index+1)
+				}
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name         string
+		countSkipped bool
+		skips        int
+		want         session.Type
+		wantSkipped  int
+	}{
+		{name: "skipped work does not count", skips: 7, want: session.Break, wantSkipped: 4},
+		{name: "skipped work counts", countSkipped: true, skips: 7, want: session.LongBreak, wantSkipped: 4},
+		{name: "one skip", skips: 1, want: session.Break, wantSkipped: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e, _ := newTestEngine(t, func(s *config.Settings) {
+				s.CountSkipped = test.countSkipped
+			})
+
+			for range test.skips {
+				if e.NextCompletes() {
+					t.Fatalf("Next of %v would complete it", e.SessionType())
+				}
+				e.Next()
+			}
+
+			if got := e.SessionType(); got != test.want {
+				t.Errorf("session is %v, want %v", got, test.want)
+			}
+			if got := e.SkippedWorkSessions(); got != test.wantSkipped {
+				t.Errorf("skipped work sessions = %d, want %d", got, test.wantSkipped)
+			}
+			if got := e.TotalWorkSessions(); got != 0 {
+				t.Errorf("completed work sessions = %d, want 0", got)
+			}
+		})
+	}
+}
+
+func TestFlowtime(t *testing.T) {
+	tests := []struct {
+		name          string
+		ratio         float64
+		worked        time.Duration
+		wantCompleted int
+		wantBreak     time.Duration
+	}{
+		{name: "first bracket", worked: 20 * time.Minute, wantCompleted: 1, wantBreak: 5 * time.Minute},
+		{name: "second bracket", worked: 30 * time.Minute, wantCompleted: 1, wantBreak: 8 * time.Minute},
+		{name: "last bracket", worked: 2 * time.Hour, wantCompleted: 1, wantBreak: 15 * time.Minute},
+		{name: "ratio", ratio: 0.2, worked: 40 * time.Minute, wantCompleted: 1, wantBreak: 8 * time.Minute},
+		// the break of the settings follows work that was skipped
+		{name: "not started", worked: 0, wantCompleted: 0, wantBreak: 5 * time.Minute},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e, clock := newTestEngine(t, func(s *config.Settings) {
+				s.Mode = config.FlowtimeMode
+				s.Flowtime.BreakRatio = test.ratio
+			})
+			e.Start()
+
+			// flowtime work never runs out
+			clock.advance(test.worked)
+			if e.Tick() {
+				t.Fatal("flowtime work completed on Tick")
+			}
+
+			if got := e.Elapsed(); got != test.worked {
+				t.Errorf("elapsed = %v, want %v", got, test.worked)
+			}
+
+			e.Next()
+
+			if got := e.State().Completed[session.Work]; got != test.wantCompleted {
+				t.Errorf("completed work = %d, want %d", got, test.wantCompleted)
+			}
+			if e.SessionType() != session.Break {
+				t.Fatalf("session is %v, want a break", e.SessionType())
+			}
+			if got := e.Duration(); got != test.wantBreak {
+				t.Errorf("break = %v, want %v", got, test.wantBreak)
+			}
+		})
+	}
+}
+
+func TestOvertime(t *testing.T) {
+	bus := event.NewBus()
+
+	var (
+		mu     sync.Mutex
+		events []event.Event
+	)
+	bus.Subscribe("test", func(e event.Event) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	})
+
+	s := config.DefaultSettings()
+	s.Overtime[session.Work] = true
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)}
+	current := "work"
+	e := New(&s, WithClock(clock), WithBus(bus), WithProfile(func() string { return current }))
+	e.Start()
+
+	runOut(e, clock)
+
+	if e.SessionType() != session.Work || !e.InOvertime() {
+		t.Fatalf("session %v in overtime %v at its end, want work in overtime", e.SessionType(), e.InOvertime())
+	}
+	if !e.NextCompletes() {
+		t.Error("Next would skip a session in overtime")
+	}
+
+	clock.advance(3 * time.Minute)
+	if e.Tick() {
+		t.Fatal("a session in overtime completed on Tick")
+	}
+	if got := e.Overtime(); got != 3*time.Minute {
+		t.Errorf("overtime = %v, want 3m", got)
+	}
+
+	// the session is counted in the profile it completes in
+	current = "study"
+	e.Next()
+	bus.Close(time.Second)
+
+	if e.SessionType() != session.Break || e.InOvertime() {
+		t.Errorf("after Next the session is %v in overtime %v, want a break", e.SessionType(), e.InOvertime())
+	}
+
+	var completed *event.Completed
+	started := false
+	for _, e := range events {
+		switch e := e.(type) {
+		case event.OvertimeStarted:
+			started = true
+		case event.Completed:
+			completed = &e
+		}
+	}
+
+	if !started {
+		t.Error("OvertimeStarted was not published")
+	}
+	if completed == nil {
+		t.Fatal("Completed was not published")
+	}
+
+	want := event.Completed{
+		At:               clock.now,
+		Profile:          "study",
+		SessionType:      session.Work,
+		Duration:         25 * time.Minute,
+		Overtime:         3 * time.Minute,
+		Next:             session.Break,
+		NotifiedOvertime: true,
+	}
+	if *completed != want {
+		t.Errorf("Completed = %+v, want %+v", *completed, want)
+	}
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"time"
+)
+
+// CountingUp reports whether the current session is flowtime work, which
+// has no fixed end: its duration is 0 and Remaining goes below zero.
+func (e *Engine) CountingUp() bool {
+	return e.settings.IsFlowtime() && e.state.SessionType == session.Work
+}
+
+// Elapsed is the time spent in the current session.
+func (e *Engine) Elapsed() time.Duration {
+	return e.state.Duration - e.Remaining()
+}
+
+// EarnedBreak is the break computed from the last flowtime work session.
+func (e *Engine) EarnedBreak() time.Duration {
+	return e.state.EarnedBreak
+}
+
+func (e *Engine) durationOf(sessionType session.Type) time.Duration {
+	if !e.settings.IsFlowtime() {
+		return e.settings.GetDuration(sessionType)
+	}
+
+	if sessionType == session.Work {
+		return 0
+	}
+
+	if e.state.EarnedBreak > 0 {
+		return e.state.EarnedBreak
+	}
+
+	return e.settings.GetDuration(sessionType)
+}
+
+// sessionTypes are the sessions of the current mode, flowtime has no long
+// breaks.
+func (e *Engine) sessionTypes() []session.Type {
+	if e.settings.IsFlowtime() {
+		return []session.Type{session.Work, session.Break}
+	}
+
+	return session.Types()
+}
+
+func (e *Engine) adjustElapsed(delta time.Duration) {
+	elapsed := max(e.Elapsed()+delta, 0)
+
+	e.setRemaining(-elapsed)
+	e.changed()
+}
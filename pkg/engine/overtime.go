@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"time"
+)
+
+// OvertimeEnabled reports whether the current session keeps counting past
+// its end instead of moving on to the next one.
+func (e *Engine) OvertimeEnabled() bool {
+	return e.settings.Overtime[e.state.SessionType] && !e.CountingUp()
+}
+
+// InOvertime reports whether the session has run past its end and waits
+// for Next.
+func (e *Engine) InOvertime() bool {
+	return e.state.Overtime
+}
+
+// Overtime is how long the session has run past its end.
+func (e *Engine) Overtime() time.Duration {
+	if !e.state.Overtime {
+		return 0
+	}
+
+	return max(-e.Remaining(), 0)
+}
+
+func (e *Engine) startOvertime() {
+	if e.state.Overtime {
+		return
+	}
+
+	e.state.Overtime = true
+	e.changed()
+
+	e.bus.Publish(event.OvertimeStarted{
+		At:          e.clock.Now(),
+		SessionType: e.state.SessionType,
+		Next:        e.NextSessionType(),
+	})
+}
+
+func (e *Engine) adjustOvertime(delta time.Duration) {
+	overtime := max(e.Overtime()+delta, 0)
+
+	e.setRemaining(-overtime)
+	e.changed()
+}
@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"encoding/json"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"time"
+)
+
+// State is everything an Engine needs to continue a cycle, it can be
+// stored with json.Marshal and given back to Restore.
+type State struct {
+	SessionType         session.Type `json:"session_type"`
+	PreviousSessionType session.Type `json:"previous_session_type"`
+	// Completed counts the sessions that ran out, Skipped the sessions
+	// ended before that with Next and Abandoned the started sessions that
+	// were reset or switched away from.
+	Completed map[session.Type]int `json:"completed"`
+	Skipped   map[session.Type]int `json:"skipped"`
+	Abandoned map[session.Type]int `json:"abandoned"`
+	// Cycle is the number of work sessions counted toward the long break.
+	Cycle   int  `json:"cycle"`
+	Running bool `json:"running"`
+	// Started is set once the timer of the session has run.
+	Started bool `json:"started"`
+	// Deadline is the end of the session while it is running.
+	Deadline time.Time `json:"deadline"`
+	// Remaining is the time left while the session is paused.
+	Remaining time.Duration `json:"remaining"`
+	// Duration is the length of the session including adjustments, 0 for
+	// flowtime work.
+	Duration time.Duration `json:"duration"`
+	// EarnedBreak is the break computed from the last flowtime work.
+	EarnedBreak time.Duration `json:"earned_break"`
+	// Overtime is set once the session has run past its end and waits
+	// for Next, Remaining then goes below zero.
+	Overtime bool `json:"overtime"`
+}
+
+func (s State) clone() State {
+	s.Completed = cloneCounts(s.Completed)
+	s.Skipped = cloneCounts(s.Skipped)
+	s.Abandoned = cloneCounts(s.Abandoned)
+
+	return s
+}
+
+// cloneCounts copies counts, a nil map becomes an empty one.
+func cloneCounts(counts map[session.Type]int) map[session.Type]int {
+	c := make(map[session.Type]int, len(counts))
+	for key, value := range counts {
+		c[key] = value
+	}
+
+	return c
+}
+
+// UnmarshalJSON decodes a state written by json.Marshal, the counters
+// missing in data are empty maps.
+func (s *State) UnmarshalJSON(data []byte) error {
+	type plain State
+
+	var p plain
+
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+
+	*s = State(p).clone()
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package event
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const queueSize = 64
+
+// Bus delivers events to subscribers. Every subscriber has its own queue
+// and goroutine: it receives events in the order they were published, and
+// a slow or panicking subscriber never blocks Publish or the others.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []*subscriber
+	closed      bool
+	// OnError is called from the subscriber goroutine when a handler
+	// panics or events are dropped because its queue is full.
+	OnError func(err error)
+}
+
+type subscriber struct {
+	name    string
+	queue   chan Event
+	handler func(Event)
+	dropped atomic.Int64
+	done    chan struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe calls handler with every event published after it.
+func (b *Bus) Subscribe(name string, handler func(Event)) {
+	s := &subscriber{
+		name:    name,
+		queue:   make(chan Event, queueSize),
+		handler: handler,
+		done:    make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.subscribers = append(b.subscribers, s)
+
+	go b.run(s)
+}
+
+// On subscribes to the events of a single type.
+func On[T Event](b *Bus, name string, handler func(T)) {
+	b.Subscribe(name, func(e Event) {
+		if e, ok := e.(T); ok {
+			handler(e)
+		}
+	})
+}
+
+// Publish never blocks: an event is dropped for a subscriber whose queue
+// is full. A nil bus discards events.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+
+	for _, s := range b.subscribers {
+		select {
+		case s.queue <- e:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
+
+// Close stops accepting events and waits up to timeout for the
+// subscribers to handle the queued ones.
+func (b *Bus) Close(timeout time.Duration) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	subscribers := b.subscribers
+	for _, s := range subscribers {
+		close(s.queue)
+	}
+	b.mu.Unlock()
+
+	deadline := time.After(timeout)
+
+	for _, s := range subscribers {
+		select {
+		case <-s.done:
+		case <-deadline:
+			return
+		}
+	}
+}
+
+func (b *Bus) run(s *subscriber) {
+	defer close(s.done)
+
+	for e := range s.queue {
+		if dropped := s.dropped.Swap(0); dropped > 0 {
+			b.report(fmt.Errorf("event subscriber %s is too slow, %v events dropped", s.name, dropped))
+		}
+
+		b.handle(s, e)
+	}
+}
+
+func (b *Bus) handle(s *subscriber, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.report(fmt.Errorf("event subscriber %s panicked on %T: %v", s.name, e, r))
+		}
+	}()
+
+	s.handler(e)
+}
+
+func (b *Bus) report(err error) {
+	if b.OnError != nil {
+		b.OnError(err)
+	}
+}
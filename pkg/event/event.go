@@ -0,0 +1,96 @@
+// Package event describes what happens to a session. Events are published
+// by the engine on a Bus and handled by independent subscribers such as
+// notifications and history.
+package event
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"time"
+)
+
+// Event is one of the types of this package.
+type Event interface {
+	event()
+}
+
+// SessionStarted is published when the timer of a new session starts.
+type SessionStarted struct {
+	At          time.Time
+	SessionType session.Type
+	Duration    time.Duration
+}
+
+type Paused struct {
+	At          time.Time
+	SessionType session.Type
+	Remaining   time.Duration
+}
+
+type Resumed struct {
+	At          time.Time
+	SessionType session.Type
+	Remaining   time.Duration
+}
+
+// Completed is published when a session runs out.
+type Completed struct {
+	At time.Time
+	// Profile is the profile the session was counted in, subscribers get
+	// the event after the profile may have been switched.
+	Profile     string
+	SessionType session.Type
+	Duration    time.Duration
+	// Overtime is how long the session ran past its end, Completed follows
+	// OvertimeStarted then.
+	Overtime time.Duration
+	Next     session.Type
+	// NotifiedOvertime is set when OvertimeStarted has already announced
+	// the next session, even if the overtime was taken back to zero.
+	NotifiedOvertime bool
+}
+
+// OvertimeStarted is published when a session with overtime runs out, the
+// clock keeps counting until the session is completed on keypress.
+type OvertimeStarted struct {
+	At          time.Time
+	SessionType session.Type
+	Next        session.Type
+}
+
+// Skipped is published when a session is ended before it runs out.
+type Skipped struct {
+	At          time.Time
+	SessionType session.Type
+	Elapsed     time.Duration
+	Next        session.Type
+}
+
+// Reset is published when a session is restarted or switched to by hand.
+type Reset struct {
+	At          time.Time
+	SessionType session.Type
+	Duration    time.Duration
+}
+
+type TimeAdjusted struct {
+	At          time.Time
+	SessionType session.Type
+	Delta       time.Duration
+	Remaining   time.Duration
+}
+
+type SettingsChanged struct {
+	At       time.Time
+	Settings config.Settings
+}
+
+func (SessionStarted) event()  {}
+func (Paused) event()          {}
+func (Resumed) event()         {}
+func (Completed) event()       {}
+func (OvertimeStarted) event() {}
+func (Skipped) event()         {}
+func (Reset) event()           {}
+func (TimeAdjusted) event()    {}
+func (SettingsChanged) event() {}
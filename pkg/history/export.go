@@ -0,0 +1,36 @@
+package history
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{"session", "finished_at", "duration_seconds", "overtime_seconds"}
+
+// WriteCSV writes the records with a header line, durations are in whole
+// seconds so spreadsheets can sum them.
+func WriteCSV(w io.Writer, records []Record) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		err := writer.Write([]string{
+			record.SessionType.Name(),
+			record.FinishedAt.Format(time.RFC3339),
+			strconv.Itoa(int(record.Duration.Seconds())),
+			strconv.Itoa(int(record.Overtime.Seconds())),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,114 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"github.com/borissimkin/pomogoro/pkg/profile"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const filename = "history.jsonl"
+
+// Record is a finished session, history is kept per profile as one JSON
+// object per line.
+type Record struct {
+	SessionType session.Type
+	FinishedAt  time.Time
+	Duration    time.Duration
+	// Overtime is the time spent past the end of the session, it is not
+	// part of Duration.
+	Overtime time.Duration `json:",omitempty"`
+}
+
+type Stats struct {
+	Completed map[session.Type]int
+	Focused   time.Duration
+	Overtime  time.Duration
+}
+
+func getFullPath(name string) string {
+	return filepath.Join(profile.Dir(name), filename)
+}
+
+func Append(name string, record Record) error {
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(profile.Dir(name), 0700)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(getFullPath(name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func Read(name string) ([]Record, error) {
+	file, err := os.Open(getFullPath(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []Record
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		if json.Unmarshal(scanner.Bytes(), &record) != nil {
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+func ReadStats(name string) (Stats, error) {
+	stats := Stats{Completed: make(map[session.Type]int)}
+
+	records, err := Read(name)
+	if err != nil {
+		return stats, err
+	}
+
+	for _, record := range records {
+		stats.Add(record)
+	}
+
+	return stats, nil
+}
+
+// Add counts the record in the statistics.
+func (s *Stats) Add(record Record) {
+	if s.Completed == nil {
+		s.Completed = make(map[session.Type]int)
+	}
+
+	s.Completed[record.SessionType]++
+
+	if record.SessionType == session.Work {
+		s.Focused += record.Duration
+		s.Overtime += record.Overtime
+	}
+}
@@ -0,0 +1,156 @@
+// Package i18n translates the texts of the UI. A message is identified by
+// its English text, which is also its English translation, so a message
+// missing from a catalog is shown in English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+type Language string
+
+const (
+	// Auto picks the language of the locale, see Detect.
+	Auto    Language = ""
+	English Language = "en"
+	Russian Language = "ru"
+)
+
+// Languages can be set, in the order they are offered.
+var Languages = []Language{English, Russian}
+
+// catalog translates messages of a language. plurals holds the forms of a
+// message with a count in the order of the indexes returned by plural.
+type catalog struct {
+	name     string
+	messages map[string]string
+	plurals  map[string][]string
+	plural   func(n int) int
+}
+
+var catalogs = map[Language]catalog{
+	English: {name: "English", plural: englishPlural},
+	Russian: {name: "Русский", messages: russianMessages, plurals: russianPlurals, plural: russianPlural},
+}
+
+var (
+	// mu guards current, texts of notifications are translated on the
+	// goroutines of the event bus while the settings may switch it.
+	mu      sync.RWMutex
+	current = Detect()
+)
+
+// Detect returns the language of the locale set by LC_ALL, LC_MESSAGES or
+// LANG, such as ru_RU.UTF-8. English is returned for unknown languages.
+func Detect() Language {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+
+		language, _, _ := strings.Cut(locale, "_")
+		language, _, _ = strings.Cut(language, ".")
+
+		if _, ok := catalogs[Language(language)]; ok {
+			return Language(language)
+		}
+
+		return English
+	}
+
+	return English
+}
+
+// Set switches the language of the texts, Auto detects it from the locale.
+func Set(language Language) {
+	if language == Auto {
+		language = Detect()
+	}
+
+	if _, ok := catalogs[language]; !ok {
+		language = English
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	current = language
+}
+
+func Current() Language {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current
+}
+
+// IsLanguage reports whether language can be set.
+func IsLanguage(language Language) bool {
+	_, ok := catalogs[language]
+
+	return ok || language == Auto
+}
+
+// Name is the name of language in itself, e.g. Русский.
+func Name(language Language) string {
+	return catalogs[language].name
+}
+
+// T translates message and formats it with args like fmt.Sprintf.
+func T(message string, args ...any) string {
+	if translation, ok := catalogs[Current()].messages[message]; ok {
+		message = translation
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
+
+// N translates a message with the count n in the plural form of the
+// language. singular and plural are the English forms, n is the first
+// argument of the format followed by args, e.g.
+//
+//	N("%d session", "%d sessions", n)
+func N(singular, plural string, n int, args ...any) string {
+	c := catalogs[Current()]
+
+	forms, ok := c.plurals[singular]
+	if !ok {
+		c = catalogs[English]
+		forms = []string{singular, plural}
+	}
+
+	form := forms[min(c.plural(n), len(forms)-1)]
+
+	return fmt.Sprintf(form, append([]any{n}, args...)...)
+}
+
+func englishPlural(n int) int {
+	if n == 1 {
+		return 0
+	}
+
+	return 1
+}
+
+// russianPlural picks one of the forms for 1, 21, …, for 2-4, 22-24, …
+// and for the other counts.
+func russianPlural(n int) int {
+	n = max(n, -n)
+
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return 1
+	}
+
+	return 2
+}
@@ -0,0 +1,250 @@
+package i18n
+
+var russianMessages = map[string]string{
+	// sessions and notifications
+	"Pomodoro":    "Помодоро",
+	"Short Break": "Короткий перерыв",
+	"Long Break":  "Длинный перерыв",
+	"Break":       "Перерыв",
+	"Flowtime":    "Флоутайм",
+	"Work":        "Работа",
+	"It’s time to focus and make some progress!":        "Пора сосредоточиться и продвинуться вперёд!",
+	"Take a short break to recharge and reset.":         "Сделайте короткий перерыв, чтобы восстановить силы.",
+	"Enjoy a longer break to fully unwind and refresh.": "Отдохните подольше, чтобы полностью расслабиться.",
+
+	// timer page
+	"Work sessions: %v completed, %v skipped": "Рабочие сессии: завершено %v, пропущено %v",
+	", %v abandoned":                                                        ", брошено %v",
+	"Break for this session: %v":                                            "Перерыв за эту сессию: %v",
+	"Sessions left before the long break: %v":                               "Сессий до длинного перерыва: %v",
+	"Settings are not loaded, defaults are used: %v":                        "Настройки не загружены, используются стандартные: %v",
+	"Settings are not reloaded: %v":                                         "Настройки не перезагружены: %v",
+	"Settings reloaded":                                                     "Настройки перезагружены",
+	"The session ended while you were away for %v":                          "Сессия закончилась, пока вас не было %v",
+	"You were away for %v, count it as a pause?":                            "Вас не было %v, считать это паузой?",
+	"The session counts up, it has no time to set":                          "Сессия идёт вверх, у неё нет времени для установки",
+	"The session is running, quit anyway?":                                  "Сессия идёт, всё равно выйти?",
+	"%q is not a time, type minutes such as 45 or a duration such as 1h30m": "%q — не время, введите минуты, например 45, или длительность, например 1h30m",
+	"%q is not a duration, use values like \"5m\" or \"-2m\"":               "%q — не длительность, используйте значения вроде \"5m\" или \"-2m\"",
+	"set time: ":       "время: ",
+	"minutes or 1h30m": "минуты или 1h30m",
+
+	// undo
+	"%s — u to undo":    "%s — u для отмены",
+	"Time adjusted":     "Время изменено",
+	"Time set to %v":    "Время установлено: %v",
+	"Undone: %s":        "Отменено: %s",
+	"Redone: %s":        "Повторено: %s",
+	"Finished, %s next": "Завершено, далее %s",
+	"Skipped to %s":     "Пропущено, далее %s",
+	"Reset %s":          "Сброшено: %s",
+	"Switched to %s":    "Переключено на %s",
+
+	// keys
+	"start":                    "старт",
+	"stop":                     "стоп",
+	"reset":                    "сброс",
+	"quit":                     "выход",
+	"next":                     "следующая",
+	"finish":                   "завершить",
+	"undo":                     "отменить",
+	"redo":                     "повторить",
+	"help":                     "помощь",
+	"command":                  "команда",
+	"+%v min":                  "+%v мин",
+	"-%v min":                  "-%v мин",
+	"to left session":          "к сессии слева",
+	"to right session":         "к сессии справа",
+	"settings":                 "настройки",
+	"profiles":                 "профили",
+	"count away time as pause": "считать отсутствие паузой",
+	"count away time as work":  "считать отсутствие работой",
+	"repeat the next key":      "повторить следующую клавишу",
+	"clear count":              "сбросить счётчик",
+	"set time":                 "задать время",
+	"set":                      "задать",
+	"cancel":                   "отмена",
+	"confirm":                  "подтвердить",
+	"select":                   "выбрать",
+	"clone":                    "копировать",
+	"rename":                   "переименовать",
+	"delete":                   "удалить",
+	"back":                     "назад",
+	"move up":                  "вверх",
+	"move down":                "вниз",
+	"decrease":                 "уменьшить",
+	"increase":                 "увеличить",
+	"toggle/edit":              "переключить/изменить",
+	"save":                     "сохранить",
+	"reset all to defaults":    "сбросить всё",
+	"reset to default":         "сбросить",
+	"filter":                   "фильтр",
+	"next section":             "следующий раздел",
+	"previous section":         "предыдущий раздел",
+	"complete path":            "дополнить путь",
+	"complete":                 "дополнить",
+	"previous":                 "предыдущая",
+	"yes":                      "да",
+	"no":                       "нет",
+	"close":                    "закрыть",
+
+	// modals
+	"Quit":          "Выход",
+	"Error":         "Ошибка",
+	"Command":       "Команда",
+	"… %d more":     "… ещё %d",
+	"Clone":         "Копирование",
+	"Clone %s as:":  "Копировать %s как:",
+	"Rename":        "Переименование",
+	"Rename %s to:": "Переименовать %s в:",
+	"Delete":        "Удаление",
+	"Delete profile %s with its settings and history?": "Удалить профиль %s с его настройками и историей?",
+	"Reset":                              "Сброс",
+	"Reset all settings to defaults?":    "Сбросить все настройки на стандартные?",
+	"Discard":                            "Отмена изменений",
+	"Discard unsaved changes?":           "Отменить несохранённые изменения?",
+	"Discard unsaved settings and quit?": "Отменить несохранённые настройки и выйти?",
+
+	// profiles page
+	"Profiles":      "Профили",
+	", %v overtime": ", %v сверх времени",
+
+	// stats page
+	"Statistics: %s": "Статистика: %s",
+	"Today":          "Сегодня",
+	"Last 7 days":    "Последние 7 дней",
+	"All time":       "Всё время",
+
+	// settings page
+	"Settings: %s":                           "Настройки: %s",
+	" (unsaved)":                             " (не сохранено)",
+	"Overridden by environment or flags: %s": "Заданы переменными окружения или флагами: %s",
+	"All sections":                           "Все разделы",
+	"No settings match the filter":           "Нет настроек по фильтру",
+	"↑ %d more":                              "↑ ещё %d",
+	"↓ %d more":                              "↓ ещё %d",
+	"on":                                     "вкл",
+	"off":                                    "выкл",
+	"None":                                   "Нет",
+	"default":                                "стандарт",
+	"auto":                                   "авто",
+	"custom":                                 "свои",
+	"classic":                                "классический",
+	"flowtime":                               "флоутайм",
+	"classic 25/5/15":                        "классика 25/5/15",
+	"extended 50/10/30":                      "длинные 50/10/30",
+	"Russian":                                "Русская",
+	"Ukrainian":                              "Украинская",
+	"Greek":                                  "Греческая",
+	"German":                                 "Немецкая",
+	"French AZERTY":                          "Французская AZERTY",
+	"Dvorak":                                 "Дворак",
+	"short 15/3/10":                          "короткие 15/3/10",
+	"%q is not a number":                     "%q — не число",
+	"must be from %v to %v":                  "должно быть от %v до %v",
+	"%q is not a duration, use values like \"25m\" or \"1h30m\"": "%q — не длительность, используйте значения вроде \"25m\" или \"1h30m\"",
+	"must be at least 1m": "должно быть не меньше 1m",
+	"use a color like #ba4949, or nothing for the default": "укажите цвет вроде #ba4949 или ничего для стандартного",
+	"only mp3 files can be played":                         "проигрываются только файлы mp3",
+	"no such file":                                         "нет такого файла",
+	"%s is a directory":                                    "%s — это папка",
+
+	// settings sections
+	"Timer":         "Таймер",
+	"Cycle":         "Цикл",
+	"Notifications": "Уведомления",
+	"Sound":         "Звук",
+	"Appearance":    "Вид",
+	"Keyboard":      "Клавиатура",
+	"Integrations":  "Интеграции",
+
+	// settings titles
+	"Durations preset":     "Набор длительностей",
+	"duration: Pomodoro":   "длительность: Помодоро",
+	"duration: Break":      "длительность: Перерыв",
+	"duration: Long Break": "длительность: Длинный перерыв",
+	"Mode":                 "Режим",
+	"% Flowtime break of work (None: brackets)": "% перерыва флоутайма от работы (Нет: интервалы)",
+	"Overtime: Pomodoro":                        "Сверх времени: Помодоро",
+	"Overtime: Break":                           "Сверх времени: Перерыв",
+	"Overtime: Long Break":                      "Сверх времени: Длинный перерыв",
+	"Long Break interval":                       "Интервал длинного перерыва",
+	"Count skipped sessions":                    "Учитывать пропущенные сессии",
+	"Auto start: Pomodoro":                      "Автостарт: Помодоро",
+	"Auto start: Break":                         "Автостарт: Перерыв",
+	"Auto start: Long Break":                    "Автостарт: Длинный перерыв",
+	"Push notification":                         "Push-уведомление",
+	"message: Pomodoro":                         "сообщение: Помодоро",
+	"message: Break":                            "сообщение: Перерыв",
+	"message: Long Break":                       "сообщение: Длинный перерыв",
+	"Sound notification":                        "Звуковое уведомление",
+	"Built-in sound":                            "Встроенный звук",
+	"Sound file (mp3)":                          "Звуковой файл (mp3)",
+	"Show progress bar":                         "Показывать прогресс",
+	"Mouse":                                     "Мышь",
+	"Theme":                                     "Тема",
+	"color: Pomodoro":                           "цвет: Помодоро",
+	"color: Break":                              "цвет: Перерыв",
+	"color: Long Break":                         "цвет: Длинный перерыв",
+	"Language":                                  "Язык",
+	"Keyboard layout":                           "Раскладка клавиатуры",
+	"Terminal title":                            "Заголовок терминала",
+	"Terminal progress":                         "Прогресс в терминале",
+
+	// settings descriptions
+	"Sets the durations of all sessions at once, custom when they match no preset.": "Задаёт длительности всех сессий сразу, «свои», когда они не совпадают ни с одним набором.",
+	"Length of a work session, e.g. 25m or 1h30m.":                                  "Длительность рабочей сессии, например 25m или 1h30m.",
+	"Length of a short break.":                                                      "Длительность короткого перерыва.",
+	"Length of the break after a full cycle of work sessions.":                      "Длительность перерыва после полного цикла рабочих сессий.",
+	"Classic counts every session down. Flowtime counts work up until you finish it with n and computes the break from the time worked.": "Классический режим отсчитывает каждую сессию вниз. Флоутайм считает работу вверх, пока вы не завершите её клавишей n, и вычисляет перерыв по отработанному времени.",
+	"Flowtime break as a percentage of the time worked. None uses the brackets of the config file.":                                      "Перерыв флоутайма в процентах от отработанного времени. Нет — интервалы из файла настроек.",
+	"Keep counting past the end of a work session, the next session starts when you press n.":                                            "Продолжать счёт после конца рабочей сессии, следующая начнётся по нажатию n.",
+	"Keep counting past the end of a short break.":                                                                                       "Продолжать счёт после конца короткого перерыва.",
+	"Keep counting past the end of a long break.":                                                                                        "Продолжать счёт после конца длинного перерыва.",
+	"Work sessions before a long break, None for no long breaks.":                                                                        "Рабочих сессий до длинного перерыва, Нет — без длинных перерывов.",
+	"Count work sessions skipped with n toward the long break.":                                                                          "Учитывать рабочие сессии, пропущенные клавишей n, до длинного перерыва.",
+	"Start a work session as soon as the break before it ends.":                                                                          "Начинать рабочую сессию сразу после перерыва перед ней.",
+	"Start a short break as soon as the work before it ends.":                                                                            "Начинать короткий перерыв сразу после работы перед ним.",
+	"Start a long break as soon as the work before it ends.":                                                                             "Начинать длинный перерыв сразу после работы перед ним.",
+	"Show a desktop notification when a session ends.":                                                                                   "Показывать уведомление на рабочем столе, когда сессия заканчивается.",
+	"Text of the notification that a work session starts, empty for the built-in one.":                                                   "Текст уведомления о начале рабочей сессии, пустой — встроенный.",
+	"Text of the notification that a short break starts.":                                                                                "Текст уведомления о начале короткого перерыва.",
+	"Text of the notification that a long break starts.":                                                                                 "Текст уведомления о начале длинного перерыва.",
+	"Play a sound when a session ends.":                                                                                                  "Проигрывать звук, когда сессия заканчивается.",
+	"The built-in sound: a ring, a bell or three beeps.":                                                                                 "Встроенный звук: звонок, колокольчик или три гудка.",
+	"An mp3 file to play instead of the built-in sound, tab completes the path.":                                                         "Файл mp3 вместо встроенного звука, tab дополняет путь.",
+	"Show the progress of the session under the clock.":                                                                                  "Показывать прогресс сессии под часами.",
+	"Click the tabs, the clock and the progress bar, scroll over the clock to change the time and click settings.":                       "Нажимайте на вкладки, часы и полосу прогресса, прокручивайте колесо над часами, чтобы менять время, и нажимайте на настройки.",
+	"Colors of the session tabs and progress bars, the colors below replace single ones.":                                                "Цвета вкладок и полос прогресса сессий, цвета ниже заменяют отдельные из них.",
+	"Color of the work tab and progress bar, left and right go through a palette.":                                                       "Цвет вкладки и полосы прогресса работы, влево и вправо перебирают палитру.",
+	"Color of the short break tab and progress bar.":                                                                                     "Цвет вкладки и полосы прогресса короткого перерыва.",
+	"Color of the long break tab and progress bar.":                                                                                      "Цвет вкладки и полосы прогресса длинного перерыва.",
+	"Language of the texts, auto follows the locale (LANG).":                                                                             "Язык текстов, авто — по локали (LANG).",
+	"Keys are where they are on QWERTY in the chosen layout, auto maps the letters of Cyrillic and Greek layouts.":                       "Клавиши остаются на местах QWERTY в выбранной раскладке, авто сопоставляет буквы кириллических и греческой раскладок.",
+
+	"Show the time and the session in the title of the terminal window, the title is restored on exit.":                                              "Показывать время и сессию в заголовке окна терминала, заголовок восстанавливается при выходе.",
+	"Show the progress in the taskbar button or tab of Windows Terminal, Ghostty, ConEmu or WezTerm. Other terminals may show it as a notification.": "Показывать прогресс на кнопке панели задач или вкладке Windows Terminal, Ghostty, ConEmu или WezTerm. Другие терминалы могут показать его как уведомление.",
+
+	// commands
+	"start or resume the session":                        "начать или продолжить сессию",
+	"pause the session":                                  "приостановить сессию",
+	"restart the session":                                "начать сессию заново",
+	"skip or finish the session":                         "пропустить или завершить сессию",
+	"add time to the session, e.g. add 5m or add -2m":    "добавить время к сессии, например add 5m или add -2m",
+	"undo the last action":                               "отменить последнее действие",
+	"redo the undone action":                             "повторить отменённое действие",
+	"change a setting of the profile, e.g. set work 50m": "изменить настройку профиля, например set work 50m",
+	"export the history of the profile":                  "выгрузить историю профиля",
+	"show a page":                                        "показать страницу",
+	"switch to a profile":                                "переключиться на профиль",
+	"quit pomogoro":                                      "выйти из pomogoro",
+	"print the state of the running timer":               "вывести состояние запущенного таймера",
+	"Exported the history of %s to %s":                   "История %s выгружена в %s",
+}
+
+// russianPlurals hold the forms for 1, 2-4 and 5 items.
+var russianPlurals = map[string][]string{
+	"Skipped %d session to %s": {"Пропущена %d сессия, далее %s", "Пропущено %d сессии, далее %s", "Пропущено %d сессий, далее %s"},
+	"%d pomodoro, %v focused":  {"%d помидор, %v в фокусе", "%d помидора, %v в фокусе", "%d помидоров, %v в фокусе"},
+	", %d break":               {", %d перерыв", ", %d перерыва", ", %d перерывов"},
+}
@@ -0,0 +1,43 @@
+// Package keyboard maps key presses of other keyboard layouts to the keys
+// at the same place on QWERTY, so key bindings are declared once for QWERTY
+// and keep their place on the keyboard.
+package keyboard
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/keyboard/layout"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var current = layout.Map(layout.Auto)
+
+// Set switches the layout that key presses are mapped from.
+func Set(l layout.Layout) {
+	current = layout.Map(l)
+}
+
+// Normalize returns msg as if it was typed on QWERTY. Only runes are
+// mapped, keys such as enter or ctrl+r are the same on every layout.
+func Normalize(msg tea.KeyMsg) tea.KeyMsg {
+	if msg.Type != tea.KeyRunes || len(current) == 0 {
+		return msg
+	}
+
+	runes := make([]rune, len(msg.Runes))
+	for index, r := range msg.Runes {
+		runes[index] = r
+		if mapped, ok := current[r]; ok {
+			runes[index] = mapped
+		}
+	}
+
+	msg.Runes = runes
+
+	return msg
+}
+
+// Matches is key.Matches for msg mapped to QWERTY, text typed into inputs
+// is left as it is.
+func Matches(msg tea.KeyMsg, bindings ...key.Binding) bool {
+	return key.Matches(Normalize(msg), bindings...)
+}
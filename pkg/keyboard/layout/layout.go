@@ -0,0 +1,87 @@
+// Package layout describes keyboard layouts by the QWERTY keys at the
+// same places, it is kept apart from the key handling so settings can
+// name a layout without depending on a UI.
+package layout
+
+import "unicode"
+
+type Layout string
+
+const (
+	// Auto maps the letters of the non-Latin layouts, they can not be
+	// mistaken for QWERTY keys.
+	Auto      Layout = ""
+	QWERTY    Layout = "qwerty"
+	Russian   Layout = "russian"
+	Ukrainian Layout = "ukrainian"
+	Greek     Layout = "greek"
+	German    Layout = "german"
+	AZERTY    Layout = "azerty"
+	Dvorak    Layout = "dvorak"
+)
+
+// Layouts can be set, in the order they are offered.
+var Layouts = []Layout{QWERTY, Russian, Ukrainian, Greek, German, AZERTY, Dvorak}
+
+var names = map[Layout]string{
+	QWERTY:    "QWERTY",
+	Russian:   "Russian",
+	Ukrainian: "Ukrainian",
+	Greek:     "Greek",
+	German:    "German",
+	AZERTY:    "French AZERTY",
+	Dvorak:    "Dvorak",
+}
+
+// IsLayout reports whether layout can be set.
+func IsLayout(layout Layout) bool {
+	_, ok := names[layout]
+
+	return ok || layout == Auto
+}
+
+// Name is the English name of layout, e.g. French AZERTY.
+func Name(layout Layout) string {
+	return names[layout]
+}
+
+// Map returns the runes of layout mapped to QWERTY. Auto joins the
+// non-Latin layouts, which map no ASCII runes.
+func Map(layout Layout) map[rune]rune {
+	if layout != Auto {
+		return layouts[layout]
+	}
+
+	m := make(map[rune]rune)
+	for _, nonLatin := range []Layout{Russian, Ukrainian, Greek} {
+		for from, to := range layouts[nonLatin] {
+			m[from] = to
+		}
+	}
+
+	return m
+}
+
+// newLayout maps the runes of a layout to the QWERTY runes at the same
+// place, the layout rows follow qwertyRows. Runes of a non-Latin layout
+// only map when they are not ASCII, they are typed with the Latin layout
+// switched on as well.
+func newLayout(rows [2]string, nonLatin bool) map[rune]rune {
+	m := make(map[rune]rune)
+
+	for index, row := range rows {
+		qwerty := []rune(qwertyRows[index])
+
+		for position, r := range []rune(row) {
+			if r == qwerty[position] || (nonLatin && r <= unicode.MaxASCII) {
+				continue
+			}
+
+			if _, ok := m[r]; !ok {
+				m[r] = qwerty[position]
+			}
+		}
+	}
+
+	return m
+}
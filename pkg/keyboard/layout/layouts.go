@@ -0,0 +1,35 @@
+package layout
+
+// qwertyRows are the keys from ` to / without and with shift, row by row.
+var qwertyRows = [2]string{
+	"`1234567890-=qwertyuiop[]\\asdfghjkl;'zxcvbnm,./",
+	"~!@#$%^&*()_+QWERTYUIOP{}|ASDFGHJKL:\"ZXCVBNM<>?",
+}
+
+var layouts = map[Layout]map[rune]rune{
+	QWERTY: {},
+	Russian: newLayout([2]string{
+		"ё1234567890-=йцукенгшщзхъ\\фывапролджэячсмитьбю.",
+		"Ё!\"№;%:?*()_+ЙЦУКЕНГШЩЗХЪ/ФЫВАПРОЛДЖЭЯЧСМИТЬБЮ,",
+	}, true),
+	Ukrainian: newLayout([2]string{
+		"'1234567890-=йцукенгшщзхїґфівапролджєячсмитьбю.",
+		"₴!\"№;%:?*()_+ЙЦУКЕНГШЩЗХЇҐФІВАПРОЛДЖЄЯЧСМИТЬБЮ,",
+	}, true),
+	Greek: newLayout([2]string{
+		"`1234567890-=;ςερτυθιοπ[]\\ασδφγηξκλ΄'ζχψωβνμ,./",
+		"~!@#$%^&*()_+:΅ΕΡΤΥΘΙΟΠ{}|ΑΣΔΦΓΗΞΚΛ¨\"ΖΧΨΩΒΝΜ<>?",
+	}, true),
+	German: newLayout([2]string{
+		"^1234567890ß´qwertzuiopü+#asdfghjklöäyxcvbnm,.-",
+		"°!\"§$%&/()=?`QWERTZUIOPÜ*'ASDFGHJKLÖÄYXCVBNM;:_",
+	}, false),
+	AZERTY: newLayout([2]string{
+		"²&é\"'(-è_çà)=azertyuiop^$*qsdfghjklmùwxcvbn,;:!",
+		"³1234567890°+AZERTYUIOP¨£µQSDFGHJKLM%WXCVBN?./§",
+	}, false),
+	Dvorak: newLayout([2]string{
+		"`1234567890[]',.pyfgcrl/=\\aoeuidhtns-;qjkxbmwvz",
+		"~!@#$%^&*(){}\"<>PYFGCRL?+|AOEUIDHTNS_:QJKXBMWVZ",
+	}, false),
+}
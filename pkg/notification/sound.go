@@ -3,15 +3,18 @@ package notification
 import (
 	"bytes"
 	"embed"
+	"github.com/borissimkin/pomogoro/pkg/config"
 	"github.com/ebitengine/oto/v3"
 	"github.com/hajimehoshi/go-mp3"
 	"io"
+	"os"
 	"time"
 )
 
 var Assets embed.FS
 
 type Player struct {
+	context     *oto.Context
 	player      *oto.Player
 	initialized bool
 }
@@ -21,37 +24,75 @@ func NewSoundPlayer() *Player {
 		initialized: false,
 	}
 }
+
 func (s *Player) InitSoundContext() {
-	fileBytes, err := Assets.ReadFile("assets/ring.mp3")
+	op := &oto.NewContextOptions{}
+
+	op.SampleRate = sampleRate
+
+	op.ChannelCount = channelCount
+
+	op.Format = oto.FormatSignedInt16LE
+
+	otoCtx, readyChan, err := oto.NewContext(op)
 	if err != nil {
 		return
 	}
+	<-readyChan
 
-	fileBytesReader := bytes.NewReader(fileBytes)
+	s.context = otoCtx
+	s.Load("", config.RingTone)
+}
 
-	decodedMp3, err := mp3.NewDecoder(fileBytesReader)
-	if err != nil {
-		panic("mp3.NewDecoder failed: " + err.Error())
+// Load makes path the sound to play, the built-in tone is used for an
+// empty path and when the file can not be played.
+func (s *Player) Load(path string, tone config.Tone) {
+	if s.context == nil {
+		return
 	}
 
-	op := &oto.NewContextOptions{}
+	if path != "" {
+		if sound, err := readFile(path); err == nil {
+			s.use(sound)
+			return
+		}
+	}
 
-	op.SampleRate = 44100
+	sound, err := builtIn(tone)
+	if err != nil {
+		s.initialized = false
+		return
+	}
 
-	op.ChannelCount = 2
+	s.use(sound)
+}
 
-	op.Format = oto.FormatSignedInt16LE
+func (s *Player) use(sound io.ReadSeeker) {
+	s.player = s.context.NewPlayer(sound)
+	s.initialized = true
+}
 
-	otoCtx, readyChan, err := oto.NewContext(op)
+func readFile(path string) (io.ReadSeeker, error) {
+	fileBytes, err := os.ReadFile(path)
 	if err != nil {
-		return
+		return nil, err
 	}
-	<-readyChan
 
-	player := otoCtx.NewPlayer(decodedMp3)
+	return mp3.NewDecoder(bytes.NewReader(fileBytes))
+}
 
-	s.player = player
-	s.initialized = true
+// builtIn is the tone, the ring for an unknown one.
+func builtIn(tone config.Tone) (io.ReadSeeker, error) {
+	if notes, ok := tones[tone]; ok {
+		return bytes.NewReader(synthesize(notes)), nil
+	}
+
+	fileBytes, err := Assets.ReadFile("assets/ring.mp3")
+	if err != nil {
+		return nil, err
+	}
+
+	return mp3.NewDecoder(bytes.NewReader(fileBytes))
 }
 
 func (s *Player) Play() {
@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"encoding/binary"
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"math"
+	"time"
+)
+
+const (
+	sampleRate   = 44100
+	channelCount = 2
+	toneVolume   = 0.4
+)
+
+// note is a sine of frequency that fades out exponentially with decay per
+// second, a zero frequency is silence.
+type note struct {
+	frequency float64
+	length    time.Duration
+	decay     float64
+}
+
+// tones are the built-in sounds other than the ring, which is an mp3.
+var tones = map[config.Tone][]note{
+	config.BellTone: {
+		{frequency: 880, length: 1500 * time.Millisecond, decay: 3},
+	},
+	config.BeepTone: {
+		{frequency: 1000, length: 150 * time.Millisecond},
+		{length: 100 * time.Millisecond},
+		{frequency: 1000, length: 150 * time.Millisecond},
+		{length: 100 * time.Millisecond},
+		{frequency: 1000, length: 150 * time.Millisecond},
+	},
+}
+
+// synthesize renders notes as signed 16 bit little endian stereo samples,
+// the format of the sound context.
+func synthesize(notes []note) []byte {
+	var samples []byte
+
+	for _, n := range notes {
+		count := int(n.length.Seconds() * sampleRate)
+		// the last milliseconds fade to zero so the note does not click
+		fade := sampleRate / 200
+
+		for index := 0; index < count; index++ {
+			t := float64(index) / sampleRate
+			value := toneVolume * math.Sin(2*math.Pi*n.frequency*t) * math.Exp(-n.decay*t)
+
+			if left := count - index; left < fade {
+				value *= float64(left) / float64(fade)
+			}
+
+			sample := uint16(int16(value * math.MaxInt16))
+			for channel := 0; channel < channelCount; channel++ {
+				samples = binary.LittleEndian.AppendUint16(samples, sample)
+			}
+		}
+	}
+
+	return samples
+}
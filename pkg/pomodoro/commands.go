@@ -0,0 +1,77 @@
+package pomodoro
+
+import (
+	"errors"
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/command"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+	"time"
+)
+
+// commandDoneMsg wakes the model after a command has changed the engine
+// outside of Update, so the state is published and the tick rescheduled.
+type commandDoneMsg struct{}
+
+func commandDone() tea.Msg {
+	return commandDoneMsg{}
+}
+
+// Commands are the actions of the page for the command line, they work
+// on any page.
+func (m *Model) Commands() []command.Command {
+	return []command.Command{
+		m.command("start", "", "start or resume the session", func([]string) (tea.Cmd, error) {
+			if !m.engine.Running() {
+				m.engine.Toggle()
+			}
+			return nil, nil
+		}),
+		m.command("stop", "", "pause the session", func([]string) (tea.Cmd, error) {
+			if m.engine.Running() {
+				m.engine.Toggle()
+			}
+			return nil, nil
+		}),
+		m.command("reset", "", "restart the session", func([]string) (tea.Cmd, error) {
+			return m.undoable(m.engine.Reset, m.describeSession("Reset %s")), nil
+		}),
+		m.command("next", "", "skip or finish the session", func([]string) (tea.Cmd, error) {
+			finished := m.engine.CountingUp() || m.engine.InOvertime()
+			return m.undoable(m.engine.Next, m.describeNext(finished)), nil
+		}),
+		m.command("add", "add <duration>", "add time to the session, e.g. add 5m or add -2m", func(args []string) (tea.Cmd, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("usage: add <duration>")
+			}
+
+			delta, err := time.ParseDuration(args[0])
+			if err != nil {
+				return nil, errors.New(i18n.T("%q is not a duration, use values like \"5m\" or \"-2m\"", args[0]))
+			}
+
+			m.adjust(delta)
+			return nil, nil
+		}),
+		m.command("undo", "", "undo the last action", func([]string) (tea.Cmd, error) {
+			return m.undoLast(), nil
+		}),
+		m.command("redo", "", "redo the undone action", func([]string) (tea.Cmd, error) {
+			return m.redoLast(), nil
+		}),
+	}
+}
+
+func (m *Model) command(name, usage, description string, run func(args []string) (tea.Cmd, error)) command.Command {
+	return command.Command{
+		Name:        name,
+		Usage:       usage,
+		Description: description,
+		Run: func(ctx *command.Context, args []string) error {
+			cmd, err := run(args)
+			ctx.Do(tea.Batch(cmd, commandDone))
+
+			return err
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package pomodoro
+
+import (
+	"errors"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCount keeps a mistyped count from skipping a day of sessions.
+const maxCount = 999
+
+var countStyles = lipgloss.NewStyle().Bold(true)
+
+// addCount appends a typed digit to the pending count.
+func (m *Model) addCount(digit string) {
+	value, _ := strconv.Atoi(digit)
+
+	m.count = min(m.count*10+value, maxCount)
+	m.keymap.ClearCount.SetEnabled(m.count > 0)
+}
+
+// takeCount returns the pending count, 1 when there is none, and clears it.
+func (m *Model) takeCount() int {
+	count := max(m.count, 1)
+	m.clearCount()
+
+	return count
+}
+
+func (m *Model) clearCount() {
+	m.count = 0
+	m.keymap.ClearCount.SetEnabled(false)
+}
+
+// skip goes to the next session count times as one action to undo.
+func (m *Model) skip(count int) tea.Cmd {
+	finished := m.engine.NextCompletes()
+
+	if count == 1 {
+		return m.undoable(m.engine.Next, m.describeNext(finished))
+	}
+
+	return m.undoable(func() {
+		for range count {
+			m.engine.Next()
+		}
+	}, func() string {
+		return i18n.N("Skipped %d session to %s", "Skipped %d sessions to %s", count, i18n.T(m.engine.Session().Title))
+	})
+}
+
+// setTime sets the time left of the session, e.g. after 45t or t 45.
+func (m *Model) setTime(remaining time.Duration) tea.Cmd {
+	if m.engine.CountingUp() {
+		return m.showToast(i18n.T("The session counts up, it has no time to set"))
+	}
+
+	return m.undoable(func() { m.engine.SetRemaining(remaining) }, func() string {
+		return i18n.T("Time set to %v", formatTime(remaining))
+	})
+}
+
+func (m *Model) startEntry() tea.Cmd {
+	m.entering = true
+	m.entry = textinput.New()
+	m.entry.Prompt = i18n.T("set time: ")
+	m.entry.Placeholder = i18n.T("minutes or 1h30m")
+
+	return m.entry.Focus()
+}
+
+// updateEntry handles a key while the time is typed in.
+func (m *Model) updateEntry(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case keyboard.Matches(msg, m.entryKeymap.Cancel):
+		m.entering = false
+		return nil
+	case keyboard.Matches(msg, m.entryKeymap.Confirm):
+		remaining, err := parseEntry(m.entry.Value())
+		if err != nil {
+			return m.showToast(err.Error())
+		}
+
+		m.entering = false
+		return m.setTime(remaining)
+	}
+
+	var cmd tea.Cmd
+	m.entry, cmd = m.entry.Update(msg)
+
+	return cmd
+}
+
+// parseEntry reads a number of minutes or a duration such as 1h30m.
+func parseEntry(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+
+	if minutes, err := strconv.Atoi(text); err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	if remaining, err := time.ParseDuration(text); err == nil && remaining > 0 {
+		return remaining, nil
+	}
+
+	return 0, errors.New(i18n.T("%q is not a time, type minutes such as 45 or a duration such as 1h30m", text))
+}
+
+// renderHelp shows the typed count before the keys it applies to, or the
+// time being typed in.
+func (m *Model) renderHelp() string {
+	if m.entering {
+		return m.entry.View() + "\n" + m.help.View(m.entryKeymap)
+	}
+
+	if m.count > 0 {
+		return countStyles.Render(strconv.Itoa(m.count)) + " " + m.help.View(m.keymap)
+	}
+
+	return m.help.View(m.keymap)
+}
@@ -0,0 +1,36 @@
+package pomodoro
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEntry(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{text: "45", want: 45 * time.Minute},
+		{text: " 45 ", want: 45 * time.Minute},
+		{text: "1h30m", want: 90 * time.Minute},
+		{text: "90s", want: 90 * time.Second},
+		{text: "0", wantErr: true},
+		{text: "-5", wantErr: true},
+		{text: "-2m", wantErr: true},
+		{text: "", wantErr: true},
+		{text: "abc", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := parseEntry(test.text)
+		if (err != nil) != test.wantErr {
+			t.Errorf("parseEntry(%q) error = %v, want error %v", test.text, err, test.wantErr)
+			continue
+		}
+
+		if got != test.want {
+			t.Errorf("parseEntry(%q) = %v, want %v", test.text, got, test.want)
+		}
+	}
+}
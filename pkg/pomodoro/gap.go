@@ -0,0 +1,52 @@
+package pomodoro
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+	"time"
+)
+
+// sleepThreshold is how late a tick may arrive before the delay is treated
+// as a system sleep or a stopped process rather than a slow tick.
+const sleepThreshold = time.Minute
+
+// checkGap notices that the program was not running for a while. A session
+// that should have ended meanwhile is finished right away unless it goes
+// into overtime, otherwise the user is asked whether the time away was a
+// pause.
+func (m *Model) checkGap(now time.Time) tea.Cmd {
+	if !m.engine.Running() || m.ticker.wakeAt.IsZero() {
+		return nil
+	}
+
+	gap := now.Sub(m.ticker.wakeAt)
+	if gap < sleepThreshold {
+		return nil
+	}
+
+	if m.engine.Remaining() <= 0 && !m.engine.CountingUp() && !m.engine.OvertimeEnabled() {
+		m.resolveGap(false)
+		return m.showToast(i18n.T("The session ended while you were away for %v", formatTime(gap)))
+	}
+
+	m.gap += gap
+	m.keymap.GapPause.SetEnabled(true)
+	m.keymap.GapWork.SetEnabled(true)
+
+	return nil
+}
+
+// resolveGap closes the prompt, a pause moves the deadline by the time away.
+func (m *Model) resolveGap(asPause bool) {
+	if asPause && m.gap > 0 {
+		m.engine.Extend(m.gap)
+	}
+
+	m.gap = 0
+	m.keymap.GapPause.SetEnabled(false)
+	m.keymap.GapWork.SetEnabled(false)
+}
+
+func renderGapPrompt(gap time.Duration) string {
+	return gapStyles.Render(i18n.T("You were away for %v, count it as a pause?", formatTime(gap)))
+}
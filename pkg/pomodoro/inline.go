@@ -0,0 +1,58 @@
+package pomodoro
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"strconv"
+	"strings"
+)
+
+const inlineProgressBarWidth = 20
+
+var inlineTimerStyles = lipgloss.NewStyle().Bold(true)
+
+// SetInline shows the page on a single line for --inline: the tab of the
+// session, the clock and a short progress bar. The line stays among the
+// output of the shell, so clicks can not be placed and the mouse is off.
+// The settings and profiles pages and the command line take more than a
+// line, their keys are turned off.
+func (m *Model) SetInline(inline bool) {
+	m.inline = inline
+	m.keymap.Settings.SetEnabled(!inline)
+	m.keymap.Profiles.SetEnabled(!inline)
+	m.keymap.Command.SetEnabled(!inline)
+	m.progress.Width = min(m.progress.Width, m.progressBarMaxWidth())
+}
+
+func (m *Model) progressBarMaxWidth() int {
+	if m.inline {
+		return inlineProgressBarWidth
+	}
+
+	return progressBarMaxWidth
+}
+
+// inlineView ends the line with what waits for input or was just done,
+// the help is left out.
+func (m *Model) inlineView() string {
+	parts := []string{renderClock(m, inlineTimerStyles)}
+
+	if m.engine.Settings().ShowProgressBar && !m.engine.CountingUp() {
+		parts = append(parts, renderProgressBar(m))
+	}
+
+	switch {
+	case m.entering:
+		parts = append(parts, m.entry.View())
+	case m.count > 0:
+		parts = append(parts, countStyles.Render(strconv.Itoa(m.count)))
+	case m.gap > 0:
+		parts = append(parts, renderGapPrompt(m.gap))
+	case m.toast != "":
+		parts = append(parts, toastStyles.Render(m.toast))
+	case m.settingsErr != nil:
+		parts = append(parts, renderSettingsError(m.settingsErr))
+	}
+
+	// the tab has a margin of its own
+	return renderTab(m.engine, m.engine.Session()) + strings.Join(parts, " ")
+}
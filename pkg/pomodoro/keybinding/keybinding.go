@@ -1,7 +1,7 @@
 package keybinding
 
 import (
-	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
 	"github.com/charmbracelet/bubbles/key"
 )
 
@@ -12,27 +12,59 @@ type KeyMap struct {
 	Stop     key.Binding
 	Reset    key.Binding
 	Next     key.Binding
+	Undo     key.Binding
+	Redo     key.Binding
 	Up       key.Binding
 	Down     key.Binding
 	Left     key.Binding
 	Right    key.Binding
 	Settings key.Binding
+	Profiles key.Binding
 	Help     key.Binding
+	Command  key.Binding
 	Quit     key.Binding
+	GapPause key.Binding
+	GapWork  key.Binding
+	// Count keys type a number that repeats the next key, e.g. 10k.
+	Count      key.Binding
+	ClearCount key.Binding
+	SetTime    key.Binding
+}
+
+// EntryKeyMap is used while the time of the session is typed in.
+type EntryKeyMap struct {
+	Confirm key.Binding
+	Cancel  key.Binding
+}
+
+func (k EntryKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Confirm, k.Cancel}
+}
+
+func (k EntryKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
+		k.GapPause,
+		k.GapWork,
+		k.ClearCount,
 		k.Start,
 		k.Stop,
 		k.Reset,
 		k.Help,
+		k.Command,
 		k.Next,
+		k.Undo,
+		k.Redo,
 		k.Left,
 		k.Right,
 		k.Up,
 		k.Down,
+		k.SetTime,
 		k.Settings,
+		k.Profiles,
 		k.Quit,
 	}
 }
@@ -40,57 +72,110 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
+		{k.Count, k.ClearCount, k.SetTime},
 		{k.Start, k.Stop, k.Reset, k.Next},
-		{k.Help, k.Settings, k.Quit},
+		{k.Undo, k.Redo},
+		{k.Help, k.Settings, k.Profiles, k.Quit, k.Command},
+		{k.GapPause, k.GapWork},
 	}
 }
 
 func InitKeys() KeyMap {
 	return KeyMap{
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", i18n.T("command")),
+		),
 		Start: key.NewBinding(
 			key.WithKeys(" "),
-			key.WithHelp("␣", "start"),
+			key.WithHelp("␣", i18n.T("start")),
 		),
 		Stop: key.NewBinding(
 			key.WithKeys(" "),
-			key.WithHelp("␣", "stop"),
+			key.WithHelp("␣", i18n.T("stop")),
 		),
 		Reset: key.NewBinding(
-			key.WithKeys("r", "к"),
-			key.WithHelp("r", "reset"),
+			key.WithKeys("r"),
+			key.WithHelp("r", i18n.T("reset")),
 		),
 		Quit: key.NewBinding(
-			key.WithKeys("q", "й", "ctrl+c"),
-			key.WithHelp("q", "quit"),
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", i18n.T("quit")),
 		),
 		Next: key.NewBinding(
-			key.WithKeys("n", "т"),
-			key.WithHelp("n", "next"),
+			key.WithKeys("n"),
+			key.WithHelp("n", i18n.T("next")),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", i18n.T("undo")),
+		),
+		Redo: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", i18n.T("redo")),
 		),
 		Help: key.NewBinding(
 			key.WithKeys("/", "?"),
-			key.WithHelp("?", "help"),
+			key.WithHelp("?", i18n.T("help")),
 		),
 
 		Up: key.NewBinding(
-			key.WithKeys("up", "k", "л", "w", "ц"),
-			key.WithHelp("↑/w/k", fmt.Sprintf("+%v min", DefaultStepMinutes)),
+			key.WithKeys("up", "k", "w"),
+			key.WithHelp("↑/w/k", i18n.T("+%v min", DefaultStepMinutes)),
 		),
 		Down: key.NewBinding(
-			key.WithKeys("down", "j", "о", "s", "ы"),
-			key.WithHelp("↓/s/j", fmt.Sprintf("-%v min", DefaultStepMinutes)),
+			key.WithKeys("down", "j", "s"),
+			key.WithHelp("↓/s/j", i18n.T("-%v min", DefaultStepMinutes)),
 		),
 		Left: key.NewBinding(
-			key.WithKeys("left", "h", "р", "a", "ф"),
-			key.WithHelp("←/a/h", "to left session"),
+			key.WithKeys("left", "h", "a"),
+			key.WithHelp("←/a/h", i18n.T("to left session")),
 		),
 		Right: key.NewBinding(
-			key.WithKeys("right", "l", "д", "d", "в"),
-			key.WithHelp("→/d/l", "to right session"),
+			key.WithKeys("right", "l", "d"),
+			key.WithHelp("→/d/l", i18n.T("to right session")),
 		),
 		Settings: key.NewBinding(
-			key.WithKeys("i", "ш"),
-			key.WithHelp("i", "settings"),
+			key.WithKeys("i"),
+			key.WithHelp("i", i18n.T("settings")),
+		),
+		GapPause: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", i18n.T("count away time as pause")),
+		),
+		GapWork: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", i18n.T("count away time as work")),
+		),
+		Profiles: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", i18n.T("profiles")),
+		),
+		Count: key.NewBinding(
+			key.WithKeys("0", "1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("0-9", i18n.T("repeat the next key")),
+		),
+		ClearCount: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", i18n.T("clear count")),
+			key.WithDisabled(),
+		),
+		SetTime: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", i18n.T("set time")),
+		),
+	}
+}
+
+func InitEntryKeys() EntryKeyMap {
+	return EntryKeyMap{
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", i18n.T("set")),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", i18n.T("cancel")),
 		),
 	}
 }
@@ -2,14 +2,19 @@ package pomodoro
 
 import (
 	"github.com/borissimkin/pomogoro/pkg/app"
-	"github.com/borissimkin/pomogoro/pkg/notification"
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/engine"
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
 	"github.com/borissimkin/pomogoro/pkg/pomodoro/keybinding"
+	"github.com/borissimkin/pomogoro/pkg/profile"
 	"github.com/borissimkin/pomogoro/pkg/router"
 	"github.com/borissimkin/pomogoro/pkg/settings"
+	"github.com/borissimkin/pomogoro/pkg/status"
 	"github.com/charmbracelet/bubbles/help"
-	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
-	"github.com/charmbracelet/bubbles/timer"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"time"
 )
@@ -18,140 +23,266 @@ const (
 	progressBarMaxWidth = 43
 )
 
+// Model is the main page, a view over an engine.Engine.
 type Model struct {
 	progress    progress.Model
-	timer       timer.Model
-	initTime    time.Duration
-	soundPlayer *notification.Player
+	engine      *engine.Engine
+	ticker      ticker
 	keymap      keybinding.KeyMap
 	help        help.Model
-	pomodoro    *Pomodoro
 	router      *router.Router
+	published   *status.State
+	settingsErr error
+
+	pollID          int
+	settingsModTime time.Time
+	toast           string
+	toastID         int
+	gap             time.Duration
+	undo            undoStack
+	// count is typed before a key to repeat it, entry is the time typed
+	// after t.
+	count       int
+	entry       textinput.Model
+	entering    bool
+	entryKeymap keybinding.EntryKeyMap
+	// seeking is set while the progress bar is dragged.
+	seeking bool
+	// terminalTitle and terminalProgress were last sent to the terminal.
+	terminalTitle    string
+	terminalProgress string
+	// inline shows the page on a single line, see SetInline.
+	inline bool
+}
+
+// initPomodoro reloads the settings and reports whether the current
+// session has to be reset.
+func (m *Model) initPomodoro() bool {
+	s, err := settings.NewSettings()
+	m.settingsErr = err
+
+	return m.applySettings(s)
 }
 
-func (m *Model) initPomodoro() {
-	m.pomodoro = NewPomodoro(settings.NewSettings())
+// applySettings passes s to the engine, the keyboard and the texts, it
+// reports whether the current session has to be reset.
+func (m *Model) applySettings(s *config.Settings) bool {
+	keyboard.Set(s.KeyboardLayout)
+
+	language := i18n.Current()
+	i18n.Set(s.Language)
+
+	reset := m.engine.SetSettings(s)
+	if i18n.Current() != language {
+		m.initKeys()
+	}
+
+	return reset
+}
+
+// initKeys builds the key bindings in the language of the texts and turns
+// them on for the state of the page.
+func (m *Model) initKeys() {
+	m.keymap = keybinding.InitKeys()
+	m.entryKeymap = keybinding.InitEntryKeys()
+
+	m.updateKeys()
+	m.updateUndoKeys()
+	m.keymap.ClearCount.SetEnabled(m.count > 0)
+	m.keymap.GapPause.SetEnabled(m.gap > 0)
+	m.keymap.GapWork.SetEnabled(m.gap > 0)
+	m.SetInline(m.inline)
 }
 
 func (m *Model) Init() tea.Cmd {
-	m.initPomodoro()
-	setTime(m, m.pomodoro.getDuration())
-	return tea.Batch(tea.ClearScreen, m.timer.Init())
+	return tea.Batch(m.scheduleTick(), m.startPolling(), m.mouseMode())
 }
 
-func getIncreasedTime(timeout time.Duration, minutes time.Duration) time.Duration {
-	return timeout + minutes*time.Minute
+// Enter applies the settings when the page is shown, they may have been
+// edited on the settings page.
+func (m *Model) Enter() tea.Cmd {
+	if m.initPomodoro() {
+		m.changeSession(m.engine.Restart)
+	}
+	m.publishState()
+	return tea.Batch(m.mouseMode(), m.updateTerminal())
 }
 
-func getDecreasedTime(timeout time.Duration, minutes time.Duration) time.Duration {
-	return timeout - minutes*time.Minute
+func (m *Model) remaining() time.Duration {
+	return max(m.engine.Remaining(), 0)
 }
 
-func setTime(m *Model, duration time.Duration) {
-	m.timer.Timeout = duration
-	m.initTime = duration
+// changeSession runs an action that replaces the current session, a
+// pending question about time away no longer applies to it.
+func (m *Model) changeSession(action func()) {
+	m.resolveGap(false)
+	action()
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	m.publishState()
+	cmd = tea.Batch(cmd, m.updateTerminal())
+
+	if m.ticker.dirty {
+		cmd = tea.Batch(cmd, m.scheduleTick())
+	}
+
+	return model, cmd
+}
+
+func (m *Model) updateKeys() {
+	m.keymap.Stop.SetEnabled(m.engine.Running())
+	m.keymap.Start.SetEnabled(!m.engine.Running())
+
+	if m.engine.CountingUp() || m.engine.InOvertime() {
+		m.keymap.Next.SetHelp("n", i18n.T("finish"))
+	} else {
+		m.keymap.Next.SetHelp("n", i18n.T("next"))
+	}
+}
+
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.help.Width = msg.Width
 
-		m.progress.Width = msg.Width
-		if m.progress.Width > progressBarMaxWidth {
-			m.progress.Width = progressBarMaxWidth
-		}
+		m.progress.Width = min(msg.Width, m.progressBarMaxWidth())
 		return m, nil
 
-	case timer.TickMsg:
-		var cmd tea.Cmd
-		m.timer, cmd = m.timer.Update(msg)
+	case settings.SavedMsg:
+		return m, m.reloadSettings()
 
-		return m, cmd
+	case settingsPollMsg:
+		if msg.id != m.pollID {
+			return m, nil
+		}
 
-	case timer.StartStopMsg:
-		var cmd tea.Cmd
-		m.timer, cmd = m.timer.Update(msg)
-		m.keymap.Stop.SetEnabled(m.timer.Running())
-		m.keymap.Start.SetEnabled(!m.timer.Running())
-		return m, cmd
+		return m, tea.Batch(m.reloadSettings(), pollSettings(m.pollID))
 
-	case timer.TimeoutMsg:
-		nextSession := m.pomodoro.nextSession()
-		notifyParams := m.pomodoro.sessions[nextSession].NotifyParams
-		if m.pomodoro.settings.Notification.Push {
-			notification.Notify(notifyParams.Title, notifyParams.Message)
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
 		}
-		if m.pomodoro.settings.Notification.Sound {
-			m.soundPlayer.Play()
+		return m, nil
+
+	case tea.FocusMsg:
+		m.ticker.SetFocused(true)
+		return m, nil
+
+	case tea.BlurMsg:
+		m.ticker.SetFocused(false)
+		return m, nil
+
+	case tickMsg:
+		if msg.tag != m.ticker.tag {
+			return m, nil
 		}
-		setTime(m, m.pomodoro.getDuration())
 
-		if !m.pomodoro.settings.AutoStart[nextSession] {
-			return m, m.timer.Stop()
+		cmd := m.checkGap(m.engine.Now())
+
+		if m.engine.Tick() {
+			m.resolveGap(false)
 		}
 
-		return m, nil
+		return m, tea.Batch(cmd, m.scheduleTick())
+
+	case tea.MouseMsg:
+		return m, m.updateMouse(msg)
 
 	case tea.KeyMsg:
+		if m.entering {
+			return m, m.updateEntry(msg)
+		}
+
+		if keyboard.Matches(msg, m.keymap.Count) {
+			m.addCount(keyboard.Normalize(msg).String())
+			return m, nil
+		}
+
+		if keyboard.Matches(msg, m.keymap.ClearCount) {
+			m.clearCount()
+			return m, nil
+		}
+
+		typed := m.count > 0
+		count := m.takeCount()
+
 		switch {
-		case key.Matches(msg, m.keymap.Settings):
-			return m.router.To(app.SettingsPageName)
-		case key.Matches(msg, m.keymap.Help):
+		case keyboard.Matches(msg, m.keymap.GapPause):
+			m.resolveGap(true)
+		case keyboard.Matches(msg, m.keymap.GapWork):
+			m.resolveGap(false)
+		case keyboard.Matches(msg, m.keymap.Settings):
+			return m, m.router.Push(app.SettingsPageName)
+		case keyboard.Matches(msg, m.keymap.Profiles):
+			return m, m.router.Push(app.ProfilesPageName)
+		case keyboard.Matches(msg, m.keymap.Help):
 			m.help.ShowAll = !m.help.ShowAll
-		case key.Matches(msg, m.keymap.Quit):
+		case keyboard.Matches(msg, m.keymap.Command):
+			return m, m.router.OpenCommandLine()
+		case keyboard.Matches(msg, m.keymap.Quit):
+			if m.engine.Running() {
+				return m, m.router.Open(router.Confirm(i18n.T("Quit"), i18n.T("The session is running, quit anyway?"), func() tea.Cmd {
+					return tea.Quit
+				}))
+			}
 			return m, tea.Quit
-		case key.Matches(msg, m.keymap.Reset):
-			setTime(m, m.pomodoro.getDuration())
-		case key.Matches(msg, m.keymap.Start, m.keymap.Stop):
-			return m, m.timer.Toggle()
-		case key.Matches(msg, m.keymap.Next):
-			m.pomodoro.nextSession()
-			setTime(m, m.pomodoro.getDuration())
-		case key.Matches(msg, m.keymap.Right):
-			m.pomodoro.setSession(getSessionType(m.pomodoro.currentSessionType + 1))
-			setTime(m, m.pomodoro.getDuration())
-		case key.Matches(msg, m.keymap.Left):
-			m.pomodoro.setSession(getSessionType(m.pomodoro.currentSessionType - 1))
-			setTime(m, m.pomodoro.getDuration())
-		case key.Matches(msg, m.keymap.Up):
-			newTimeout := getIncreasedTime(m.timer.Timeout, keybinding.DefaultStepMinutes)
-			m.initTime += keybinding.DefaultStepMinutes * time.Minute
-			m.timer.Timeout = newTimeout
-		case key.Matches(msg, m.keymap.Down):
-			newTimeout := getDecreasedTime(m.timer.Timeout, keybinding.DefaultStepMinutes)
-			m.initTime -= keybinding.DefaultStepMinutes * time.Minute
-			if newTimeout < 0 {
-				m.pomodoro.nextSession()
-				setTime(m, m.pomodoro.getDuration())
-			} else {
-				m.timer.Timeout = newTimeout
+		case keyboard.Matches(msg, m.keymap.Reset):
+			return m, m.undoable(m.engine.Reset, m.describeSession("Reset %s"))
+		case keyboard.Matches(msg, m.keymap.Start, m.keymap.Stop):
+			m.engine.Toggle()
+		case keyboard.Matches(msg, m.keymap.Next):
+			return m, m.skip(count)
+		case keyboard.Matches(msg, m.keymap.Right):
+			return m, m.undoable(func() { m.engine.Move(count) }, m.describeSession("Switched to %s"))
+		case keyboard.Matches(msg, m.keymap.Left):
+			return m, m.undoable(func() { m.engine.Move(-count) }, m.describeSession("Switched to %s"))
+		case keyboard.Matches(msg, m.keymap.Up):
+			m.adjust(time.Duration(count*keybinding.DefaultStepMinutes) * time.Minute)
+		case keyboard.Matches(msg, m.keymap.Down):
+			m.adjust(-time.Duration(count*keybinding.DefaultStepMinutes) * time.Minute)
+		case keyboard.Matches(msg, m.keymap.SetTime):
+			if typed {
+				return m, m.setTime(time.Duration(count) * time.Minute)
 			}
+			return m, m.startEntry()
+		case keyboard.Matches(msg, m.keymap.Undo):
+			return m, m.undoLast()
+		case keyboard.Matches(msg, m.keymap.Redo):
+			return m, m.redoLast()
 		}
 	}
 
 	return m, nil
 }
 
-func NewModel(r *router.Router) *Model {
-	soundPlayer := notification.NewSoundPlayer()
-	soundPlayer.InitSoundContext()
-
-	p := NewPomodoro(settings.NewSettings())
-
-	initTime := p.getDuration()
+// NewModel starts the first work session, side effects of the session
+// events are left to the subscribers of bus.
+func NewModel(r *router.Router, bus *event.Bus) *Model {
+	s, err := settings.NewSettings()
 
 	model := &Model{
-		progress:    progress.New(progress.WithSolidFill(p.currentSession().BackgroundColor), progress.WithoutPercentage()),
-		timer:       timer.NewWithInterval(initTime, time.Millisecond),
-		initTime:    initTime,
-		pomodoro:    p,
-		soundPlayer: soundPlayer,
+		ticker:      newTicker(),
 		keymap:      keybinding.InitKeys(),
 		help:        help.New(),
+		entryKeymap: keybinding.InitEntryKeys(),
 		router:      r,
+		settingsErr: err,
 	}
-	model.keymap.Start.SetEnabled(false)
+
+	model.engine = engine.New(s, engine.WithBus(bus), engine.WithProfile(profile.Current), engine.WithCallbacks(engine.Callbacks{
+		OnChange: func(state engine.State) {
+			model.ticker.dirty = true
+			model.undo.update(state)
+			model.updateKeys()
+			model.updateUndoKeys()
+		},
+	}))
+	model.updateUndoKeys()
+	model.progress = progress.New(progress.WithSolidFill(model.engine.Session().BackgroundColor), progress.WithoutPercentage())
+	model.engine.Start()
+	model.resolveGap(false)
 
 	return model
 }
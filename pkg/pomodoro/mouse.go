@@ -0,0 +1,110 @@
+package pomodoro
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/pomodoro/keybinding"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"time"
+)
+
+// Lines of the view that take clicks, see View.
+const (
+	tabsLine  = 0
+	clockLine = 2
+	barLine   = 3
+)
+
+const timerWidth = 40
+
+// mouseMode turns the mouse on or off as set in the settings.
+func (m *Model) mouseMode() tea.Cmd {
+	if m.engine.Settings().Mouse && !m.inline {
+		return tea.EnableMouseCellMotion
+	}
+
+	return tea.DisableMouse
+}
+
+// updateMouse switches sessions with clicks on the tabs, starts and stops
+// with clicks on the clock, changes the time with the wheel over it and
+// seeks with clicks and drags on the progress bar.
+func (m *Model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	if !m.engine.Settings().Mouse || m.inline {
+		return nil
+	}
+
+	if m.seeking {
+		return m.seek(msg)
+	}
+
+	if msg.Action != tea.MouseActionPress {
+		return nil
+	}
+
+	step := keybinding.DefaultStepMinutes * time.Minute
+
+	switch {
+	case msg.Y == clockLine && msg.Button == tea.MouseButtonWheelUp:
+		m.adjust(step)
+	case msg.Y == clockLine && msg.Button == tea.MouseButtonWheelDown:
+		m.adjust(-step)
+	case msg.Button != tea.MouseButtonLeft:
+		return nil
+	case msg.Y == tabsLine:
+		if sessionType, ok := m.tabAt(msg.X); ok && sessionType != m.engine.SessionType() {
+			return m.undoable(func() { m.engine.SetSession(sessionType) }, m.describeSession("Switched to %s"))
+		}
+	case msg.Y == clockLine && msg.X < timerWidth:
+		m.engine.Toggle()
+	case msg.Y == barLine && msg.X < m.progress.Width && m.canSeek():
+		before := m.engine.State()
+		m.seeking = true
+		cmd := m.seek(msg)
+		m.undo.push(before, i18n.T("Time set to %v", formatTime(m.remaining())))
+		m.updateUndoKeys()
+
+		return cmd
+	}
+
+	return nil
+}
+
+func (m *Model) tabAt(x int) (session.Type, bool) {
+	left := 0
+
+	for _, item := range m.engine.Sessions() {
+		right := left + lipgloss.Width(renderTab(m.engine, item))
+		if x >= left && x < right {
+			return item.SessionType, true
+		}
+
+		left = right
+	}
+
+	return 0, false
+}
+
+// canSeek reports whether the progress bar is shown and stands for the time
+// left of the session.
+func (m *Model) canSeek() bool {
+	return m.engine.Settings().ShowProgressBar && !m.engine.CountingUp() && !m.engine.InOvertime()
+}
+
+// seek moves the deadline so the bar is filled up to the pointer, the
+// duration of the session stays. The drag ends on release.
+func (m *Model) seek(msg tea.MouseMsg) tea.Cmd {
+	if msg.Action == tea.MouseActionRelease || !m.canSeek() {
+		m.seeking = false
+		return nil
+	}
+
+	width := max(m.progress.Width, 1)
+	filled := float64(min(max(msg.X+1, 0), width)) / float64(width)
+
+	remaining := max(time.Duration(float64(m.engine.Duration())*(1-filled)), time.Second)
+	m.engine.Extend(remaining - m.engine.Remaining())
+
+	return nil
+}
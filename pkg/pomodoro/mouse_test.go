@@ -0,0 +1,112 @@
+package pomodoro
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/router"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("POMOGORO_MOUSE", "true")
+	t.Setenv("POMOGORO_SHOW_PROGRESS_BAR", "true")
+
+	r := router.NewRouter()
+	m := NewModel(&r, event.NewBus())
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	return m
+}
+
+func press(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+}
+
+func TestMouseLines(t *testing.T) {
+	m := newTestModel(t)
+	lines := strings.Split(m.View(), "\n")
+
+	if !strings.Contains(lines[tabsLine], i18n.T(session.WorkSession.Title)) {
+		t.Errorf("line %d = %q, want the tabs", tabsLine, lines[tabsLine])
+	}
+	if !strings.Contains(lines[clockLine], formatTime(m.remaining())) {
+		t.Errorf("line %d = %q, want the clock", clockLine, lines[clockLine])
+	}
+	if !strings.Contains(lines[barLine], "░") {
+		t.Errorf("line %d = %q, want the progress bar", barLine, lines[barLine])
+	}
+}
+
+func TestMouseClicks(t *testing.T) {
+	tests := []struct {
+		name  string
+		click func(m *Model) tea.MouseMsg
+		check func(t *testing.T, m *Model)
+	}{
+		{
+			name: "tab",
+			click: func(m *Model) tea.MouseMsg {
+				return press(lipgloss.Width(renderTab(m.engine, m.engine.Session()))+1, tabsLine)
+			},
+			check: func(t *testing.T, m *Model) {
+				if m.engine.SessionType() != session.Break {
+					t.Errorf("session = %v, want %v", m.engine.SessionType(), session.Break)
+				}
+			},
+		},
+		{
+			name: "clock",
+			click: func(m *Model) tea.MouseMsg {
+				return press(timerWidth/2, clockLine)
+			},
+			check: func(t *testing.T, m *Model) {
+				if m.engine.Running() {
+					t.Error("the session is still running")
+				}
+			},
+		},
+		{
+			name: "bar",
+			click: func(m *Model) tea.MouseMsg {
+				return press(m.progress.Width/2-1, barLine)
+			},
+			check: func(t *testing.T, m *Model) {
+				// the bar is filled up to and including the clicked cell
+				filled := float64(m.progress.Width/2) / float64(m.progress.Width)
+				want := time.Duration(float64(m.engine.Duration()) * (1 - filled))
+				if diff := m.engine.Remaining() - want; diff < -time.Second || diff > time.Second {
+					t.Errorf("remaining = %v, want about %v", m.engine.Remaining(), want)
+				}
+			},
+		},
+		{
+			name: "empty line",
+			click: func(m *Model) tea.MouseMsg {
+				return press(0, clockLine-1)
+			},
+			check: func(t *testing.T, m *Model) {
+				if m.engine.SessionType() != session.Work || !m.engine.Running() {
+					t.Error("a click outside of the tabs, clock and bar changed the session")
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestModel(t)
+			m.Update(test.click(m))
+			test.check(t, m)
+		})
+	}
+}
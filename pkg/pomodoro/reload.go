@@ -0,0 +1,72 @@
+package pomodoro
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/settings"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+	"time"
+)
+
+const (
+	settingsPollInterval = 2 * time.Second
+	toastDuration        = 3 * time.Second
+)
+
+type settingsPollMsg struct {
+	id int
+}
+
+type toastExpiredMsg struct {
+	id int
+}
+
+func pollSettings(id int) tea.Cmd {
+	return tea.Tick(settingsPollInterval, func(time.Time) tea.Msg {
+		return settingsPollMsg{id: id}
+	})
+}
+
+func (m *Model) startPolling() tea.Cmd {
+	m.pollID++
+	m.settingsModTime = settings.ModTime()
+
+	return pollSettings(m.pollID)
+}
+
+func (m *Model) showToast(text string) tea.Cmd {
+	m.toast = text
+	m.toastID++
+
+	id := m.toastID
+
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// reloadSettings applies external edits of the settings file, the timer is
+// only reset when the duration of the current session has changed.
+func (m *Model) reloadSettings() tea.Cmd {
+	modTime := settings.ModTime()
+	if modTime.Equal(m.settingsModTime) {
+		return nil
+	}
+
+	m.settingsModTime = modTime
+
+	// the settings in use stay when the file is broken, the error tells
+	// what to fix in it
+	s, err := settings.NewSettings()
+	if err != nil {
+		return m.showToast(i18n.T("Settings are not reloaded: %v", strings.ReplaceAll(err.Error(), "\n", "; ")))
+	}
+	m.settingsErr = nil
+
+	if m.applySettings(s) {
+		m.changeSession(m.engine.Restart)
+	}
+	m.ticker.dirty = true
+
+	return tea.Batch(m.showToast(i18n.T("Settings reloaded")), m.mouseMode())
+}
@@ -0,0 +1,47 @@
+package pomodoro
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/profile"
+	"github.com/borissimkin/pomogoro/pkg/status"
+)
+
+// publishState shares the timer with `pomogoro status`, the file is only
+// rewritten when the state changes other than by the running clock.
+func (m *Model) publishState() {
+	state := m.state()
+
+	if m.published != nil && m.published.Equal(state) {
+		return
+	}
+
+	if status.Write(state) == nil {
+		m.published = &state
+	}
+}
+
+func (m *Model) state() status.State {
+	state := status.State{
+		Profile:     profile.Current(),
+		SessionType: m.engine.SessionType(),
+		Running:     m.engine.Running(),
+		Remaining:   m.remaining(),
+		Flowtime:    m.engine.Settings().IsFlowtime(),
+		Initial:     m.engine.Duration(),
+		Completed:   m.engine.CompletedInCycle(),
+		Goal:        m.engine.Settings().WorkSessionsUntilLongBreak,
+		UpdatedAt:   m.engine.Now(),
+	}
+
+	if m.engine.CountingUp() {
+		state.CountingUp = true
+		state.Remaining = m.engine.Elapsed()
+	}
+
+	if m.engine.InOvertime() {
+		state.CountingUp = true
+		state.Overtime = true
+		state.Remaining = m.engine.Overtime()
+	}
+
+	return state
+}
@@ -0,0 +1,80 @@
+package pomodoro
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/status"
+	"github.com/borissimkin/pomogoro/pkg/terminal"
+	tea "github.com/charmbracelet/bubbletea"
+	"io"
+)
+
+// updateTerminal shows the timer in the title and the taskbar progress of
+// the terminal as enabled in the settings. Sequences are only sent when
+// what they show changes, the title of the terminal is saved before it is
+// first replaced.
+func (m *Model) updateTerminal() tea.Cmd {
+	var cmds []tea.Cmd
+
+	title := ""
+	if m.engine.Settings().TerminalTitle {
+		title = status.Title(m.state())
+	}
+
+	if title != m.terminalTitle {
+		switch {
+		case m.terminalTitle == "":
+			cmds = append(cmds, tea.Sequence(terminal.Write(terminal.SaveTitle), tea.SetWindowTitle(title)))
+		case title == "":
+			cmds = append(cmds, terminal.Write(terminal.RestoreTitle))
+		default:
+			cmds = append(cmds, tea.SetWindowTitle(title))
+		}
+
+		m.terminalTitle = title
+	}
+
+	progress := ""
+	if m.engine.Settings().TerminalProgress {
+		progress = m.progressSequence()
+	}
+
+	if progress != m.terminalProgress {
+		if progress == "" {
+			cmds = append(cmds, terminal.Write(terminal.Progress(terminal.ProgressNone, 0)))
+		} else {
+			cmds = append(cmds, terminal.Write(progress))
+		}
+
+		m.terminalProgress = progress
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// progressSequence shows the bar of the page: paused, in overtime as an
+// error and with no end while counting up.
+func (m *Model) progressSequence() string {
+	percent := int(getPercent(m) * 100)
+
+	switch {
+	case !m.engine.Running():
+		return terminal.Progress(terminal.ProgressPaused, percent)
+	case m.engine.InOvertime():
+		return terminal.Progress(terminal.ProgressError, percent)
+	case m.engine.CountingUp():
+		return terminal.Progress(terminal.ProgressIndeterminate, 0)
+	}
+
+	return terminal.Progress(terminal.ProgressNormal, percent)
+}
+
+// RestoreTerminal puts back the title and removes the progress after the
+// program has exited.
+func (m *Model) RestoreTerminal() {
+	if m.terminalTitle != "" {
+		_, _ = io.WriteString(terminal.Stdout, terminal.RestoreTitle)
+	}
+
+	if m.terminalProgress != "" {
+		_, _ = io.WriteString(terminal.Stdout, terminal.Progress(terminal.ProgressNone, 0))
+	}
+}
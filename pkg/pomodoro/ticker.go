@@ -0,0 +1,114 @@
+package pomodoro
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"math"
+	"time"
+)
+
+const (
+	// slowTickInterval is used when nothing on the screen changes often:
+	// the timer is paused or the terminal is not focused and does not show
+	// the time in its title.
+	slowTickInterval = 5 * time.Second
+	tickSlack        = time.Millisecond
+)
+
+type tickMsg struct {
+	tag int
+}
+
+// ticker wakes the model up, the time itself is kept by the engine.
+type ticker struct {
+	focused bool
+	tag     int
+	dirty   bool
+	wakeAt  time.Time
+}
+
+func newTicker() ticker {
+	return ticker{
+		focused: true,
+		dirty:   true,
+	}
+}
+
+func (t *ticker) SetFocused(focused bool) {
+	t.focused = focused
+	t.dirty = true
+}
+
+// untilSecondChange is the delay before the truncated remaining time shown
+// by formatTime changes.
+func untilSecondChange(remaining time.Duration) time.Duration {
+	return remaining - remaining.Truncate(time.Second) + tickSlack
+}
+
+// untilElapsedSecondChange is untilSecondChange for a clock counting up.
+func untilElapsedSecondChange(elapsed time.Duration) time.Duration {
+	return time.Second - (elapsed - elapsed.Truncate(time.Second)) + tickSlack
+}
+
+// untilCellChange is the delay before the progress bar of the given width
+// fills one more cell, it mirrors the rounding of progress.Model.
+func untilCellChange(initTime, remaining time.Duration, width int) time.Duration {
+	if initTime <= 0 || width <= 0 {
+		return math.MaxInt64
+	}
+
+	elapsed := float64(initTime - remaining)
+	cell := float64(initTime) / float64(width)
+	filled := math.Round(elapsed / cell)
+	next := (filled + 0.5) * cell
+
+	return time.Duration(next-elapsed) + tickSlack
+}
+
+// scheduleTick wakes the model only when the visible output can change or
+// the session ends. Pending ticks of earlier schedules are dropped by tag.
+func (m *Model) scheduleTick() tea.Cmd {
+	t := &m.ticker
+	t.dirty = false
+	t.tag++
+
+	now := m.engine.Now()
+	delay := slowTickInterval
+	focused := t.focused || m.engine.Settings().TerminalTitle
+
+	if m.engine.Running() && m.engine.CountingUp() {
+		delay = untilElapsedSecondChange(m.engine.Elapsed())
+		if !focused {
+			delay += slowTickInterval - time.Second
+		}
+	} else if m.engine.Running() && m.engine.InOvertime() {
+		overtime := m.engine.Overtime()
+		delay = untilElapsedSecondChange(overtime)
+
+		if !focused {
+			delay += slowTickInterval - time.Second
+		} else if m.engine.Settings().ShowProgressBar {
+			delay = min(delay, untilCellChange(m.engine.Duration(), m.engine.Duration()-overtime, m.progress.Width))
+		}
+	} else if m.engine.Running() {
+		remaining := m.engine.Remaining()
+
+		if focused {
+			delay = untilSecondChange(remaining)
+
+			if m.engine.Settings().ShowProgressBar {
+				delay = min(delay, untilCellChange(m.engine.Duration(), remaining, m.progress.Width))
+			}
+		} else {
+			delay = untilSecondChange(remaining) + slowTickInterval - time.Second
+		}
+
+		delay = max(min(delay, remaining), 0)
+	}
+
+	tag := t.tag
+	t.wakeAt = now.Add(delay)
+
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return tickMsg{tag: tag}
+	})
+}
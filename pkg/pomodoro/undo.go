@@ -0,0 +1,163 @@
+package pomodoro
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/engine"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+	"time"
+)
+
+const undoLimit = 50
+
+// action is an engine state from before a change, label describes the
+// change.
+type action struct {
+	state engine.State
+	label string
+}
+
+// undoStack keeps states from before destructive actions, a new action
+// drops what could be redone. A completed session has been announced and
+// recorded in the history, so states from before it are not kept.
+type undoStack struct {
+	undo []action
+	redo []action
+	// completed is the number of sessions completed in the current state.
+	completed int
+}
+
+// push keeps state to go back to and reports whether it did, a state from
+// before a completed session is not kept.
+func (s *undoStack) push(state engine.State, label string) bool {
+	if completedSessions(state) < s.completed {
+		return false
+	}
+
+	s.undo = append(s.undo, action{state: state, label: label})
+	if len(s.undo) > undoLimit {
+		s.undo = s.undo[len(s.undo)-undoLimit:]
+	}
+
+	s.redo = nil
+
+	return true
+}
+
+// update follows the state of the engine, the states kept so far are
+// dropped when a session is completed.
+func (s *undoStack) update(state engine.State) {
+	completed := completedSessions(state)
+	if completed > s.completed {
+		s.undo = nil
+		s.redo = nil
+	}
+
+	s.completed = completed
+}
+
+func completedSessions(state engine.State) int {
+	completed := 0
+	for _, count := range state.Completed {
+		completed += count
+	}
+
+	return completed
+}
+
+// back returns the state to go back to from current, current can be
+// redone afterwards.
+func (s *undoStack) back(current engine.State) (action, bool) {
+	return move(&s.undo, &s.redo, current)
+}
+
+func (s *undoStack) forward(current engine.State) (action, bool) {
+	return move(&s.redo, &s.undo, current)
+}
+
+func move(from, to *[]action, current engine.State) (action, bool) {
+	if len(*from) == 0 {
+		return action{}, false
+	}
+
+	last := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, action{state: current, label: last.label})
+
+	return last, true
+}
+
+// undoable runs an action that replaces the current session so that it
+// can be undone, describe is called after it to label the change. Finishing
+// a session can not be undone.
+func (m *Model) undoable(run func(), describe func() string) tea.Cmd {
+	before := m.engine.State()
+
+	m.changeSession(run)
+
+	label := describe()
+	pushed := m.undo.push(before, label)
+	m.updateUndoKeys()
+
+	if !pushed {
+		return m.showToast(label)
+	}
+
+	return m.showToast(i18n.T("%s — u to undo", label))
+}
+
+// adjust changes the time, every step can be undone without a message.
+func (m *Model) adjust(delta time.Duration) {
+	m.undo.push(m.engine.State(), i18n.T("Time adjusted"))
+	m.updateUndoKeys()
+
+	m.engine.Adjust(delta)
+}
+
+func (m *Model) undoLast() tea.Cmd {
+	last, ok := m.undo.back(m.engine.State())
+	if !ok {
+		return nil
+	}
+
+	m.changeSession(func() { m.engine.Restore(last.state) })
+	m.updateUndoKeys()
+
+	return m.showToast(i18n.T("Undone: %s", last.label))
+}
+
+func (m *Model) redoLast() tea.Cmd {
+	next, ok := m.undo.forward(m.engine.State())
+	if !ok {
+		return nil
+	}
+
+	m.changeSession(func() { m.engine.Restore(next.state) })
+	m.updateUndoKeys()
+
+	return m.showToast(i18n.T("Redone: %s", next.label))
+}
+
+func (m *Model) updateUndoKeys() {
+	m.keymap.Undo.SetEnabled(len(m.undo.undo) > 0)
+	m.keymap.Redo.SetEnabled(len(m.undo.redo) > 0)
+}
+
+// describeNext labels Next, it finishes a session that has no end of its
+// own or has already run out and skips any other.
+func (m *Model) describeNext(finished bool) func() string {
+	return func() string {
+		if finished {
+			return i18n.T("Finished, %s next", i18n.T(m.engine.Session().Title))
+		}
+
+		return i18n.T("Skipped to %s", i18n.T(m.engine.Session().Title))
+	}
+}
+
+// describeSession labels a change with format, a message taking the
+// title of the session after it, e.g. "Reset %s".
+func (m *Model) describeSession(format string) func() string {
+	return func() string {
+		return i18n.T(format, i18n.T(m.engine.Session().Title))
+	}
+}
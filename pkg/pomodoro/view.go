@@ -2,6 +2,9 @@ package pomodoro
 
 import (
 	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/engine"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/session"
 	"github.com/charmbracelet/lipgloss"
 	"time"
 )
@@ -12,16 +15,30 @@ var (
 			MarginRight(1).
 			Foreground(lipgloss.Color("#FFFDF5")).
 			Padding(0, 1)
-	timerStyles            = lipgloss.NewStyle().Width(40).Align(lipgloss.Center).Bold(true)
+	timerStyles            = lipgloss.NewStyle().Width(timerWidth).Align(lipgloss.Center).Bold(true)
 	progressBarPausedColor = "#4b4453"
+	progressBarEmptyColor  = "#606060"
+	overtimeColor          = "#ff6f59"
+	overtimeStyles         = lipgloss.NewStyle().Foreground(lipgloss.Color(overtimeColor))
+	errorStyles            = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+	toastStyles            = lipgloss.NewStyle().Italic(true).Faint(true)
+	gapStyles              = lipgloss.NewStyle().Bold(true)
 )
 
 func isPause(m *Model) bool {
 	return m.keymap.Start.Enabled()
 }
 
+// renderProgressBar fills the bar with the session color, in overtime the
+// full bar is overflowed with the overtime color.
 func renderProgressBar(m *Model) string {
-	color := m.pomodoro.currentSession().BackgroundColor
+	color := m.engine.Session().BackgroundColor
+	m.progress.EmptyColor = progressBarEmptyColor
+
+	if m.engine.InOvertime() {
+		m.progress.EmptyColor = color
+		color = overtimeColor
+	}
 
 	if isPause(m) {
 		color = progressBarPausedColor
@@ -37,46 +54,83 @@ func formatTime(t time.Duration) string {
 }
 
 func renderTime(m *Model) string {
-	var style = timerStyles
+	return renderClock(m, timerStyles)
+}
 
-	if !m.timer.Running() {
+// renderClock shows the time left, or the time counted up, in style.
+func renderClock(m *Model, style lipgloss.Style) string {
+	if !m.engine.Running() {
 		style = style.Faint(true)
 	}
 
-	return style.Render(formatTime(m.timer.Timeout))
+	if m.engine.CountingUp() {
+		return style.Render(formatTime(m.engine.Elapsed()))
+	}
+
+	if m.engine.InOvertime() {
+		return style.Inherit(overtimeStyles).Render("+" + formatTime(m.engine.Overtime()))
+	}
+
+	return style.Render(formatTime(m.remaining()))
 }
 
 func getPercent(m *Model) float64 {
-	return float64(m.initTime-m.timer.Timeout) / float64(m.initTime)
+	if m.engine.InOvertime() {
+		if m.engine.Duration() <= 0 {
+			return 1
+		}
+
+		return min(float64(m.engine.Overtime())/float64(m.engine.Duration()), 1)
+	}
+
+	return m.engine.Progress()
 }
 
-func renderTotalSessions(p *Pomodoro) string {
-	return fmt.Sprintf("Total Work sessions: %v", p.totalWorkSessions())
+func renderTotalSessions(e *engine.Engine) string {
+	s := i18n.T("Work sessions: %v completed, %v skipped", e.TotalWorkSessions(), e.SkippedWorkSessions())
+
+	if e.AbandonedWorkSessions() > 0 {
+		s += i18n.T(", %v abandoned", e.AbandonedWorkSessions())
+	}
+
+	return s
 }
 
-func renderSessionsBeforeLongBreak(p *Pomodoro) string {
-	return fmt.Sprintf("Sessions left before the long break: %v", p.sessionsBeforeLongBreak())
+func renderEarnedBreak(e *engine.Engine) string {
+	return i18n.T("Break for this session: %v", formatTime(e.Settings().Flowtime.BreakFor(e.Elapsed())))
 }
 
-func renderSessionTypes(p *Pomodoro) string {
+func renderSessionsBeforeLongBreak(e *engine.Engine) string {
+	return i18n.T("Sessions left before the long break: %v", e.SessionsBeforeLongBreak())
+}
+
+func renderSessionTypes(e *engine.Engine) string {
 	s := ""
 
-	for _, item := range p.SliceSessions() {
-		cursor := " "
+	for _, item := range e.Sessions() {
+		s += renderTab(e, item)
+	}
 
-		var style = tabStyles.Background(lipgloss.Color(item.BackgroundColor))
+	return s
+}
 
-		if item.SessionType != p.currentSessionType {
-			style = style.
-				Faint(true)
-		} else {
-			cursor = "*"
-		}
+func renderTab(e *engine.Engine, item *session.Session) string {
+	cursor := " "
+
+	var style = tabStyles.Background(lipgloss.Color(item.BackgroundColor))
 
-		s += style.Render(fmt.Sprintf("%s %s", cursor, item.Title))
+	if item.SessionType != e.SessionType() {
+		style = style.
+			Faint(true)
+	} else {
+		cursor = "*"
 	}
 
-	return s
+	return style.Render(fmt.Sprintf("%s %s", cursor, i18n.T(item.Title)))
+}
+
+func renderSettingsError(err error) string {
+	return errorStyles.Render(i18n.T("Settings are not loaded, defaults are used: %v", err))
 }
 
 func renderBreakLine() string {
@@ -84,7 +138,11 @@ func renderBreakLine() string {
 }
 
 func (m *Model) View() string {
-	s := renderSessionTypes(m.pomodoro)
+	if m.inline {
+		return m.inlineView()
+	}
+
+	s := renderSessionTypes(m.engine)
 
 	s += renderBreakLine()
 	s += renderBreakLine()
@@ -93,21 +151,39 @@ func (m *Model) View() string {
 
 	s += renderBreakLine()
 
-	if m.pomodoro.settings.ShowProgressBar {
+	if m.engine.CountingUp() {
+		s += renderEarnedBreak(m.engine)
+		s += renderBreakLine()
+	} else if m.engine.Settings().ShowProgressBar {
 		s += renderProgressBar(m)
 		s += renderBreakLine()
 	}
 
 	s += renderBreakLine()
-	s += renderTotalSessions(m.pomodoro)
+	s += renderTotalSessions(m.engine)
 	s += renderBreakLine()
 
-	if m.pomodoro.settings.WorkSessionsUntilLongBreak > 0 {
-		s += renderSessionsBeforeLongBreak(m.pomodoro)
+	if m.engine.Settings().WorkSessionsUntilLongBreak > 0 && !m.engine.Settings().IsFlowtime() {
+		s += renderSessionsBeforeLongBreak(m.engine)
+		s += renderBreakLine()
+	}
+
+	if m.gap > 0 {
+		s += renderGapPrompt(m.gap)
+		s += renderBreakLine()
+	}
+
+	if m.toast != "" {
+		s += toastStyles.Render(m.toast)
+		s += renderBreakLine()
+	}
+
+	if m.settingsErr != nil {
+		s += renderSettingsError(m.settingsErr)
 		s += renderBreakLine()
 	}
 
-	s += m.help.View(m.keymap)
+	s += m.renderHelp()
 
 	return s
 }
@@ -0,0 +1,216 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+const (
+	Default = "default"
+	folder  = "pomogoro"
+	subdir  = "profiles"
+)
+
+var (
+	// current is read by event subscribers outside of the UI goroutine.
+	current   = Default
+	currentMu sync.RWMutex
+	validName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+
+	ErrExists    = errors.New("profile already exists")
+	ErrNotExists = errors.New("profile does not exist")
+	ErrDefault   = errors.New("default profile can not be renamed or deleted")
+	ErrInUse     = errors.New("profile is in use")
+	ErrWrongName = errors.New("profile name may contain only letters, digits, '-' and '_'")
+)
+
+// Root is the pomogoro config folder, shared by all profiles.
+func Root() string {
+	path, _ := os.UserConfigDir()
+
+	return filepath.Join(path, folder)
+}
+
+// Dir is the folder with the files of a profile. The default profile lives
+// in the root so configs created before profiles keep working.
+func Dir(name string) string {
+	if name == Default {
+		return Root()
+	}
+
+	return filepath.Join(Root(), subdir, name)
+}
+
+func Current() string {
+	currentMu.RLock()
+	defer currentMu.RUnlock()
+
+	return current
+}
+
+func Set(name string) error {
+	if err := Validate(name); err != nil {
+		return err
+	}
+
+	currentMu.Lock()
+	current = name
+	currentMu.Unlock()
+
+	return nil
+}
+
+func Validate(name string) error {
+	if !validName.MatchString(name) {
+		return ErrWrongName
+	}
+
+	return nil
+}
+
+func Exists(name string) bool {
+	if name == Default {
+		return true
+	}
+
+	info, err := os.Stat(Dir(name))
+
+	return err == nil && info.IsDir()
+}
+
+// List returns the default profile followed by the others sorted by name.
+func List() ([]string, error) {
+	names := []string{Default}
+
+	entries, err := os.ReadDir(filepath.Join(Root(), subdir))
+	if errors.Is(err, os.ErrNotExist) {
+		return names, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	others := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() && Validate(entry.Name()) == nil {
+			others = append(others, entry.Name())
+		}
+	}
+
+	sort.Strings(others)
+
+	return append(names, others...), nil
+}
+
+// Create makes a new empty profile, it runs with the default settings.
+func Create(name string) error {
+	if err := checkNew(name); err != nil {
+		return err
+	}
+
+	return os.MkdirAll(Dir(name), 0700)
+}
+
+// Clone creates a new profile dst with copies of the given files of src,
+// missing files are skipped.
+func Clone(src, dst string, files ...string) error {
+	if err := checkNew(dst); err != nil {
+		return err
+	}
+	if !Exists(src) {
+		return ErrNotExists
+	}
+
+	if err := os.MkdirAll(Dir(dst), 0700); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		err := copyFile(filepath.Join(Dir(src), file), filepath.Join(Dir(dst), file))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("clone %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+func Rename(old, new string) error {
+	if old == Default {
+		return ErrDefault
+	}
+	if err := checkNew(new); err != nil {
+		return err
+	}
+	if !Exists(old) {
+		return ErrNotExists
+	}
+
+	err := os.Rename(Dir(old), Dir(new))
+	if err != nil {
+		return err
+	}
+
+	currentMu.Lock()
+	if current == old {
+		current = new
+	}
+	currentMu.Unlock()
+
+	return nil
+}
+
+func Delete(name string) error {
+	if name == Default {
+		return ErrDefault
+	}
+	if name == Current() {
+		return ErrInUse
+	}
+	if !Exists(name) {
+		return ErrNotExists
+	}
+
+	return os.RemoveAll(Dir(name))
+}
+
+func checkNew(name string) error {
+	if err := Validate(name); err != nil {
+		return err
+	}
+	if Exists(name) {
+		return ErrExists
+	}
+
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
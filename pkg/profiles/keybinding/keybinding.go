@@ -0,0 +1,85 @@
+package keybinding
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type KeyMap struct {
+	Help    key.Binding
+	Command key.Binding
+	Select  key.Binding
+	Clone   key.Binding
+	Rename  key.Binding
+	Delete  key.Binding
+	Back    key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Quit    key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Help,
+		k.Select,
+		k.Command,
+		k.Clone,
+		k.Rename,
+		k.Delete,
+		k.Back,
+		k.Quit,
+	}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Select},
+		{k.Clone, k.Rename, k.Delete},
+		{k.Back, k.Help, k.Quit, k.Command},
+	}
+}
+
+func InitKeys() KeyMap {
+	return KeyMap{
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", i18n.T("command")),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter", " "),
+			key.WithHelp("enter", i18n.T("select")),
+		),
+		Clone: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", i18n.T("clone")),
+		),
+		Rename: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", i18n.T("rename")),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", i18n.T("delete")),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "b"),
+			key.WithHelp("esc/b", i18n.T("back")),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", i18n.T("quit")),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up", "k", "w"),
+			key.WithHelp("↑/w/k", i18n.T("move up")),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j", "s"),
+			key.WithHelp("↓/s/j", i18n.T("move down")),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("/", "?"),
+			key.WithHelp("?", i18n.T("help")),
+		),
+	}
+}
@@ -0,0 +1,239 @@
+package profiles
+
+import (
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"github.com/borissimkin/pomogoro/pkg/history"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
+	"github.com/borissimkin/pomogoro/pkg/profile"
+	"github.com/borissimkin/pomogoro/pkg/profiles/keybinding"
+	"github.com/borissimkin/pomogoro/pkg/router"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"github.com/borissimkin/pomogoro/pkg/settings"
+	"github.com/charmbracelet/bubbles/help"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"time"
+)
+
+var (
+	profilesStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("229")).
+			Background(lipgloss.Color("57")).
+			MarginLeft(2).
+			PaddingLeft(1).
+			PaddingRight(1)
+	statsStyle = lipgloss.NewStyle().Faint(true)
+	errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+)
+
+type Model struct {
+	names  []string
+	stats  map[string]history.Stats
+	cursor int
+	err    error
+	help   help.Model
+	keymap keybinding.KeyMap
+	router *router.Router
+}
+
+func (m *Model) load() {
+	names, err := profile.List()
+	if err != nil {
+		m.err = err
+		names = []string{profile.Default}
+	}
+
+	m.names = names
+	m.stats = make(map[string]history.Stats, len(names))
+
+	for index, name := range names {
+		stats, err := history.ReadStats(name)
+		if err != nil {
+			m.err = err
+		}
+
+		m.stats[name] = stats
+
+		if name == profile.Current() {
+			m.cursor = index
+		}
+	}
+
+	if m.cursor >= len(m.names) {
+		m.cursor = len(m.names) - 1
+	}
+}
+
+func (m *Model) currentName() string {
+	return m.names[m.cursor]
+}
+
+func (m *Model) clone() tea.Cmd {
+	source := m.currentName()
+
+	return m.router.Open(router.Prompt(i18n.T("Clone"), i18n.T("Clone %s as:", source), "", func(name string) error {
+		err := profile.Clone(source, name, settings.Files()...)
+		if err != nil {
+			return err
+		}
+
+		m.load()
+		m.selectName(name)
+
+		return nil
+	}))
+}
+
+func (m *Model) rename() tea.Cmd {
+	source := m.currentName()
+
+	return m.router.Open(router.Prompt(i18n.T("Rename"), i18n.T("Rename %s to:", source), source, func(name string) error {
+		err := profile.Rename(source, name)
+		if err != nil {
+			return err
+		}
+
+		m.load()
+		m.selectName(name)
+
+		return nil
+	}))
+}
+
+func (m *Model) delete() tea.Cmd {
+	name := m.currentName()
+
+	return m.router.Open(router.Confirm(i18n.T("Delete"), i18n.T("Delete profile %s with its settings and history?", name), func() tea.Cmd {
+		m.err = profile.Delete(name)
+		if m.err == nil {
+			m.load()
+		}
+
+		return nil
+	}))
+}
+
+func (m *Model) selectName(name string) {
+	for index, item := range m.names {
+		if item == name {
+			m.cursor = index
+		}
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.help.Width = msg.Width
+	case event.Completed:
+		stats := m.stats[msg.Profile]
+		stats.Add(history.Record{SessionType: msg.SessionType, Duration: msg.Duration, Overtime: msg.Overtime})
+		m.stats[msg.Profile] = stats
+	case tea.KeyMsg:
+		switch {
+		case keyboard.Matches(msg, m.keymap.Help):
+			m.help.ShowAll = !m.help.ShowAll
+		case keyboard.Matches(msg, m.keymap.Command):
+			return m, m.router.OpenCommandLine()
+		case keyboard.Matches(msg, m.keymap.Back):
+			return m, m.router.Pop()
+		case keyboard.Matches(msg, m.keymap.Quit):
+			return m, tea.Quit
+		case keyboard.Matches(msg, m.keymap.Select):
+			m.err = profile.Set(m.currentName())
+			if m.err != nil {
+				return m, nil
+			}
+			return m, m.router.Pop()
+		case keyboard.Matches(msg, m.keymap.Clone):
+			m.err = nil
+			return m, m.clone()
+		case keyboard.Matches(msg, m.keymap.Rename):
+			m.err = nil
+			return m, m.rename()
+		case keyboard.Matches(msg, m.keymap.Delete):
+			m.err = nil
+			return m, m.delete()
+		case keyboard.Matches(msg, m.keymap.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case keyboard.Matches(msg, m.keymap.Down):
+			if m.cursor < len(m.names)-1 {
+				m.cursor++
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Enter reads the profiles and their statistics again, the keys are built
+// again in case the language has changed.
+func (m *Model) Enter() tea.Cmd {
+	m.err = nil
+	m.keymap = keybinding.InitKeys()
+	m.load()
+
+	return nil
+}
+
+func renderStats(stats history.Stats) string {
+	s := i18n.N(
+		"%d pomodoro, %v focused",
+		"%d pomodoros, %v focused",
+		stats.Completed[session.Work],
+		stats.Focused.Truncate(time.Minute),
+	)
+
+	if stats.Overtime >= time.Minute {
+		s += i18n.T(", %v overtime", stats.Overtime.Truncate(time.Minute))
+	}
+
+	return statsStyle.Render(s)
+}
+
+func (m *Model) View() string {
+	s := profilesStyle.Render(i18n.T("Profiles"))
+
+	s += "\n"
+
+	for index, name := range m.names {
+		cursor := " "
+		if index == m.cursor {
+			cursor = ">"
+		}
+
+		active := " "
+		if name == profile.Current() {
+			active = "*"
+		}
+
+		s += fmt.Sprintf("%s %s %s %s\n", cursor, active, name, renderStats(m.stats[name]))
+	}
+
+	s += "\n"
+
+	if m.err != nil {
+		s += errorStyle.Render(m.err.Error())
+		s += "\n"
+	}
+
+	return s + m.help.View(m.keymap)
+}
+
+func NewModel(r *router.Router) *Model {
+	return &Model{
+		names:  []string{profile.Current()},
+		stats:  make(map[string]history.Stats),
+		help:   help.New(),
+		keymap: keybinding.InitKeys(),
+		router: r,
+	}
+}
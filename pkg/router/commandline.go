@@ -0,0 +1,152 @@
+package router
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/command"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+)
+
+const maxSuggestions = 5
+
+var (
+	suggestionStyles         = lipgloss.NewStyle().Faint(true)
+	selectedSuggestionStyles = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+)
+
+// CommandLine asks for a line of commands, entered lines are added to
+// history and can be recalled with up and down.
+func CommandLine(commands *command.Registry, history *command.History) *Modal {
+	m := newModal(commandModal, i18n.T("Command"), "")
+	m.commands = commands
+	m.history = history
+	m.entry = len(history.Lines())
+	m.input = textinput.New()
+	m.input.Prompt = ":"
+	m.suggest()
+
+	return m
+}
+
+func (m *Modal) updateCommand(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch {
+	case keyboard.Matches(msg, m.keymap.Submit):
+		return m.runCommand()
+	case keyboard.Matches(msg, m.keymap.Cancel):
+		return true, nil
+	case keyboard.Matches(msg, m.keymap.Complete):
+		m.complete()
+		return false, nil
+	case keyboard.Matches(msg, m.keymap.Previous):
+		m.recall(-1)
+		return false, nil
+	case keyboard.Matches(msg, m.keymap.Next):
+		m.recall(1)
+		return false, nil
+	}
+
+	value := m.input.Value()
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+
+	if m.input.Value() != value {
+		m.err = nil
+		m.suggest()
+	}
+
+	return false, cmd
+}
+
+// runCommand runs the line. The modal stays open with the error when the
+// command fails and turns into an alert when the command printed something.
+func (m *Modal) runCommand() (bool, tea.Cmd) {
+	line := strings.TrimSpace(m.input.Value())
+	if line == "" {
+		return true, nil
+	}
+
+	m.err = m.history.Add(line)
+	m.entry = len(m.history.Lines())
+
+	var out strings.Builder
+	ctx := &command.Context{Out: &out}
+
+	if err := m.commands.Run(ctx, line); err != nil {
+		m.err = err
+		return false, nil
+	}
+
+	if out.Len() == 0 {
+		return true, ctx.Cmd()
+	}
+
+	*m = *Alert(line, strings.TrimRight(out.String(), "\n"))
+
+	return false, ctx.Cmd()
+}
+
+func (m *Modal) suggest() {
+	m.suggestions = m.commands.Complete(m.input.Value())
+	m.selected = 0
+}
+
+// complete puts the selected suggestion into the line, pressed again it
+// goes to the next one. A single suggestion is taken at once and the
+// arguments that may follow it are suggested.
+func (m *Modal) complete() {
+	if len(m.suggestions) == 0 {
+		return
+	}
+
+	if m.input.Value() == m.suggestions[m.selected].Line {
+		m.selected = (m.selected + 1) % len(m.suggestions)
+	}
+
+	m.input.SetValue(m.suggestions[m.selected].Line)
+	m.input.CursorEnd()
+
+	if len(m.suggestions) == 1 {
+		m.suggest()
+	}
+}
+
+// recall goes through the history, past the latest line it is empty.
+func (m *Modal) recall(step int) {
+	lines := m.history.Lines()
+	m.entry = max(min(m.entry+step, len(lines)), 0)
+
+	value := ""
+	if m.entry < len(lines) {
+		value = lines[m.entry]
+	}
+
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.suggest()
+}
+
+func (m *Modal) renderSuggestions() string {
+	s := ""
+
+	first := max(m.selected-maxSuggestions+1, 0)
+	last := min(first+maxSuggestions, len(m.suggestions))
+
+	for index := first; index < last; index++ {
+		style := suggestionStyles
+		if index == m.selected {
+			style = selectedSuggestionStyles
+		}
+
+		s += "\n" + style.Render(m.suggestions[index].Description)
+	}
+
+	if hidden := len(m.suggestions) - last + first; hidden > 0 {
+		s += "\n" + suggestionStyles.Render(i18n.T("… %d more", hidden))
+	}
+
+	return s
+}
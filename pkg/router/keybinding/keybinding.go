@@ -0,0 +1,75 @@
+package keybinding
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type ModalKeyMap struct {
+	Yes      key.Binding
+	No       key.Binding
+	Submit   key.Binding
+	Cancel   key.Binding
+	Close    key.Binding
+	Complete key.Binding
+	Previous key.Binding
+	Next     key.Binding
+	Quit     key.Binding
+}
+
+func (k ModalKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Yes,
+		k.No,
+		k.Submit,
+		k.Complete,
+		k.Previous,
+		k.Next,
+		k.Cancel,
+		k.Close,
+	}
+}
+
+func (k ModalKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+func InitModalKeys() ModalKeyMap {
+	return ModalKeyMap{
+		Yes: key.NewBinding(
+			key.WithKeys("y", "enter"),
+			key.WithHelp("y", i18n.T("yes")),
+		),
+		No: key.NewBinding(
+			key.WithKeys("n", "esc"),
+			key.WithHelp("n", i18n.T("no")),
+		),
+		Submit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", i18n.T("confirm")),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", i18n.T("cancel")),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("enter", "esc", " "),
+			key.WithHelp("enter", i18n.T("close")),
+		),
+		Complete: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", i18n.T("complete")),
+		),
+		Previous: key.NewBinding(
+			key.WithKeys("up", "ctrl+p"),
+			key.WithHelp("↑", i18n.T("previous")),
+		),
+		Next: key.NewBinding(
+			key.WithKeys("down", "ctrl+n"),
+			key.WithHelp("↓", i18n.T("next")),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+		),
+	}
+}
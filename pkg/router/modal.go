@@ -0,0 +1,230 @@
+package router
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/command"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
+	"github.com/borissimkin/pomogoro/pkg/router/keybinding"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+)
+
+var (
+	modalStyles = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("57")).
+			Padding(0, 1)
+	modalTitleStyles = lipgloss.NewStyle().Bold(true)
+	modalErrorStyles = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+)
+
+const modalMinWidth = 30
+
+type modalKind int
+
+const (
+	confirmModal modalKind = iota
+	promptModal
+	alertModal
+	commandModal
+)
+
+// Modal is a dialog shown by the Router over the current route, it takes
+// all key presses until it is closed.
+type Modal struct {
+	kind     modalKind
+	title    string
+	message  string
+	input    textinput.Model
+	onYes    func() tea.Cmd
+	onSubmit func(value string) error
+	err      error
+	keymap   keybinding.ModalKeyMap
+	help     help.Model
+	// command line state, see commandline.go
+	commands    *command.Registry
+	history     *command.History
+	entry       int
+	suggestions []command.Suggestion
+	selected    int
+}
+
+func newModal(kind modalKind, title, message string) *Modal {
+	m := &Modal{
+		kind:    kind,
+		title:   title,
+		message: message,
+		keymap:  keybinding.InitModalKeys(),
+		help:    help.New(),
+	}
+
+	m.keymap.Yes.SetEnabled(kind == confirmModal)
+	m.keymap.No.SetEnabled(kind == confirmModal)
+	m.keymap.Submit.SetEnabled(kind == promptModal || kind == commandModal)
+	m.keymap.Cancel.SetEnabled(kind == promptModal || kind == commandModal)
+	m.keymap.Close.SetEnabled(kind == alertModal)
+	m.keymap.Complete.SetEnabled(kind == commandModal)
+	m.keymap.Previous.SetEnabled(kind == commandModal)
+	m.keymap.Next.SetEnabled(kind == commandModal)
+
+	return m
+}
+
+// Confirm asks a yes or no question, onYes runs in the update loop when
+// it is answered with yes.
+func Confirm(title, message string, onYes func() tea.Cmd) *Modal {
+	m := newModal(confirmModal, title, message)
+	m.onYes = onYes
+
+	return m
+}
+
+// Prompt asks for a line of text starting with value. The modal stays
+// open and shows the error when onSubmit fails.
+func Prompt(title, message, value string, onSubmit func(value string) error) *Modal {
+	m := newModal(promptModal, title, message)
+	m.onSubmit = onSubmit
+	m.input = textinput.New()
+	m.input.CharLimit = 64
+	m.input.SetValue(value)
+
+	return m
+}
+
+// Alert shows a message until it is closed.
+func Alert(title, message string) *Modal {
+	return newModal(alertModal, title, message)
+}
+
+// update handles a key press and reports whether the modal is done.
+func (m *Modal) update(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if keyboard.Matches(msg, m.keymap.Quit) {
+		return true, tea.Quit
+	}
+
+	switch m.kind {
+	case confirmModal:
+		switch {
+		case keyboard.Matches(msg, m.keymap.Yes):
+			if m.onYes != nil {
+				return true, m.onYes()
+			}
+			return true, nil
+		case keyboard.Matches(msg, m.keymap.No):
+			return true, nil
+		}
+	case promptModal:
+		switch {
+		case keyboard.Matches(msg, m.keymap.Submit):
+			m.err = m.onSubmit(m.input.Value())
+			return m.err == nil, nil
+		case keyboard.Matches(msg, m.keymap.Cancel):
+			return true, nil
+		}
+
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+
+		return false, cmd
+	case alertModal:
+		if keyboard.Matches(msg, m.keymap.Close) {
+			return true, nil
+		}
+	case commandModal:
+		return m.updateCommand(msg)
+	}
+
+	return false, nil
+}
+
+// forward passes a message that is not a key press, such as the cursor
+// blink, to the input of the modal.
+func (m *Modal) forward(msg tea.Msg) tea.Cmd {
+	if m.kind != promptModal && m.kind != commandModal {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+
+	return cmd
+}
+
+func (m *Modal) focus() tea.Cmd {
+	if m.kind == promptModal || m.kind == commandModal {
+		return m.input.Focus()
+	}
+
+	return nil
+}
+
+func (m *Modal) View() string {
+	s := modalTitleStyles.Render(m.title)
+
+	if m.message != "" {
+		s += "\n" + m.message
+	}
+
+	if m.kind == promptModal || m.kind == commandModal {
+		s += "\n" + m.input.View()
+	}
+
+	if m.kind == commandModal {
+		s += m.renderSuggestions()
+	}
+
+	if m.err != nil {
+		s += "\n" + modalErrorStyles.Render(m.err.Error())
+	}
+
+	s += "\n\n" + m.help.View(m.keymap)
+
+	return modalStyles.Width(max(lipgloss.Width(s)+2, modalMinWidth)).Render(s)
+}
+
+// inlineView puts the modal on one line without the box and the
+// suggestions, the full help is left out.
+func (m *Modal) inlineView() string {
+	parts := []string{modalTitleStyles.Render(m.title)}
+
+	if m.message != "" {
+		parts = append(parts, m.message)
+	}
+
+	if m.kind == promptModal || m.kind == commandModal {
+		parts = append(parts, m.input.View())
+	}
+
+	if m.err != nil {
+		parts = append(parts, modalErrorStyles.Render(m.err.Error()))
+	}
+
+	parts = append(parts, m.help.ShortHelpView(m.keymap.ShortHelp()))
+
+	return strings.Join(parts, " ")
+}
+
+// overlay draws box over the middle of view, the covered lines are
+// replaced as a whole.
+func overlay(view, box string, width int) string {
+	lines := strings.Split(view, "\n")
+	boxLines := strings.Split(box, "\n")
+
+	if width <= 0 {
+		width = max(lipgloss.Width(view), lipgloss.Width(box))
+	}
+
+	for len(lines) < len(boxLines) {
+		lines = append(lines, "")
+	}
+
+	top := (len(lines) - len(boxLines)) / 2
+
+	for index, line := range boxLines {
+		lines[top+index] = lipgloss.PlaceHorizontal(width, lipgloss.Center, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -1,23 +1,65 @@
 package router
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"errors"
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/command"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type RouteKey string
 
 type RouteValue tea.Model
 
+// ErrUnknownRoute is returned for a key that is not one of the routes.
+var ErrUnknownRoute = errors.New("unknown route")
+
 type Route struct {
 	Key   RouteKey
 	Value RouteValue
+	// build makes the value of a lazy route when it is first shown.
+	build func() RouteValue
 }
 
 func NewRoute(key RouteKey, value RouteValue) Route {
-	return Route{key, value}
+	return Route{Key: key, Value: value}
+}
+
+// NewLazyRoute makes a route whose value is built by build the first time
+// the route is shown.
+func NewLazyRoute(key RouteKey, build func() RouteValue) Route {
+	return Route{Key: key, build: build}
+}
+
+// Enterer is implemented by routes that act each time they become the
+// current route, e.g. to reload what other routes have changed.
+type Enterer interface {
+	Enter() tea.Cmd
 }
 
+// Leaver is implemented by routes that act when another route is shown
+// over them or replaces them.
+type Leaver interface {
+	Leave() tea.Cmd
+}
+
+// Router is the root model of the program. Routes are kept on a stack,
+// the route on top is shown and gets the input, every other message is
+// passed to all routes that have been built so their state stays current.
 type Router struct {
-	Routes       map[RouteKey]Route
-	currentRoute RouteKey
+	Routes map[RouteKey]Route
+	keys   []RouteKey
+	stack  []RouteKey
+	modal  *Modal
+	width  int
+	// size is the last window size, given to routes built after it.
+	size     *tea.WindowSizeMsg
+	commands *command.Registry
+	history  *command.History
+	// inline keeps the screen when the route changes, it is shared with
+	// the shell.
+	inline bool
 }
 
 func NewRouter() Router {
@@ -26,6 +68,7 @@ func NewRouter() Router {
 	}
 }
 
+// SetRoutes adds the routes, the first one is shown at the start.
 func (r *Router) SetRoutes(routes []Route) {
 	if len(routes) == 0 {
 		panic("routes should not be empty")
@@ -33,19 +76,216 @@ func (r *Router) SetRoutes(routes []Route) {
 
 	for _, route := range routes {
 		r.Routes[route.Key] = route
+		r.keys = append(r.keys, route.Key)
 	}
 
-	r.currentRoute = routes[0].Key
+	r.stack = []RouteKey{routes[0].Key}
 }
 
 func (r *Router) CurrentRoute() Route {
-	return r.Routes[r.currentRoute]
+	return r.Routes[r.stack[len(r.stack)-1]]
 }
 
-func (r *Router) To(key RouteKey) (tea.Model, tea.Cmd) {
-	r.currentRoute = key
+// Keys returns the keys of the routes in the order they were set.
+func (r *Router) Keys() []RouteKey {
+	return r.keys
+}
+
+// Push shows the route over the current one, Pop goes back to it.
+func (r *Router) Push(key RouteKey) tea.Cmd {
+	return r.navigate(key, func() {
+		r.stack = append(r.stack, key)
+	})
+}
 
+// Replace shows the route in place of the current one.
+func (r *Router) Replace(key RouteKey) tea.Cmd {
+	return r.navigate(key, func() {
+		r.stack[len(r.stack)-1] = key
+	})
+}
+
+// Goto shows the route right over the first one, so going back from it
+// leads to the first route.
+func (r *Router) Goto(key RouteKey) tea.Cmd {
+	return r.navigate(key, func() {
+		r.stack = r.stack[:1]
+		if key != r.stack[0] {
+			r.stack = append(r.stack, key)
+		}
+	})
+}
+
+// Pop goes back to the route under the current one, the first route is
+// never popped.
+func (r *Router) Pop() tea.Cmd {
+	if len(r.stack) == 1 {
+		return nil
+	}
+
+	return r.navigate(r.stack[len(r.stack)-2], func() {
+		r.stack = r.stack[:len(r.stack)-1]
+	})
+}
+
+// navigate leaves the current route, changes the stack and enters the new
+// current route. An unknown route is reported in an alert and nothing
+// changes.
+func (r *Router) navigate(key RouteKey, change func()) tea.Cmd {
+	if _, ok := r.Routes[key]; !ok {
+		return r.Open(Alert(i18n.T("Error"), fmt.Errorf("%w: %s", ErrUnknownRoute, key).Error()))
+	}
+
+	var cmds []tea.Cmd
+	if !r.inline {
+		cmds = append(cmds, tea.ClearScreen)
+	}
+
+	if leaver, ok := r.CurrentRoute().Value.(Leaver); ok {
+		cmds = append(cmds, leaver.Leave())
+	}
+
+	change()
+
+	return tea.Batch(append(cmds, r.enter())...)
+}
+
+// enter builds the current route if it is lazy and runs its Enter hook.
+func (r *Router) enter() tea.Cmd {
+	var cmds []tea.Cmd
+
+	key := r.stack[len(r.stack)-1]
 	route := r.Routes[key]
 
-	return route.Value, tea.Batch(tea.ClearScreen, route.Value.Init())
+	if route.Value == nil {
+		route.Value = route.build()
+		r.Routes[key] = route
+
+		cmds = append(cmds, route.Value.Init())
+
+		if r.size != nil {
+			_, cmd := route.Value.Update(*r.size)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	if enterer, ok := route.Value.(Enterer); ok {
+		cmds = append(cmds, enterer.Enter())
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// SetInline keeps the output of the shell above the program, the screen
+// is not cleared when the route changes. Modals are shown on a single line
+// in place of the route and the command line is not opened.
+func (r *Router) SetInline(inline bool) {
+	r.inline = inline
+}
+
+// SetCommands sets what can be run on the command line.
+func (r *Router) SetCommands(commands *command.Registry, history *command.History) {
+	r.commands = commands
+	r.history = history
+}
+
+// OpenCommandLine asks for a command, the routes open it on their key.
+func (r *Router) OpenCommandLine() tea.Cmd {
+	if r.commands == nil || r.inline {
+		return nil
+	}
+
+	return r.Open(CommandLine(r.commands, r.history))
+}
+
+// Open shows a modal over the current route, it replaces an open one.
+func (r *Router) Open(modal *Modal) tea.Cmd {
+	r.modal = modal
+
+	return modal.focus()
+}
+
+// Init starts the routes that are not lazy and enters the first one. The
+// screen is cleared first, like on navigation, so the first page starts at
+// the top.
+func (r *Router) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	if !r.inline {
+		cmds = append(cmds, tea.ClearScreen)
+	}
+
+	for _, route := range r.Routes {
+		if route.Value != nil {
+			cmds = append(cmds, route.Value.Init())
+		}
+	}
+
+	return tea.Batch(append(cmds, r.enter())...)
+}
+
+// Update passes input to the open modal or the current route, other
+// messages such as the window size, ticks and events go to all routes and
+// to the open modal.
+func (r *Router) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if modal := r.modal; modal != nil {
+			done, cmd := modal.update(msg)
+			// the modal may have opened another one
+			if done && r.modal == modal {
+				r.modal = nil
+			}
+
+			return r, cmd
+		}
+
+		_, cmd := r.CurrentRoute().Value.Update(msg)
+
+		return r, cmd
+	case tea.MouseMsg:
+		// a modal covers the route, clicks do not go through it
+		if r.modal != nil {
+			return r, nil
+		}
+
+		_, cmd := r.CurrentRoute().Value.Update(msg)
+
+		return r, cmd
+	case tea.WindowSizeMsg:
+		r.width = msg.Width
+		r.size = &msg
+	}
+
+	if r.modal != nil {
+		return r, tea.Batch(r.modal.forward(msg), r.broadcast(msg))
+	}
+
+	return r, r.broadcast(msg)
+}
+
+func (r *Router) broadcast(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	for _, route := range r.Routes {
+		if route.Value != nil {
+			_, cmd := route.Value.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (r *Router) View() string {
+	view := r.CurrentRoute().Value.View()
+
+	if r.modal != nil {
+		if r.inline {
+			return r.modal.inlineView()
+		}
+
+		return overlay(view, r.modal.View(), r.width)
+	}
+
+	return view
 }
@@ -1,17 +1,14 @@
 package session
 
-import (
-	"github.com/borissimkin/pomogoro/pkg/notification"
-	"sort"
-)
+import "sort"
 
 type Type int
 
 type Session struct {
 	SessionType     Type
 	Title           string
+	Icon            string
 	BackgroundColor string
-	NotifyParams    *notification.NotifyParams
 }
 
 const (
@@ -23,31 +20,31 @@ const (
 var WorkSession = Session{
 	SessionType:     Work,
 	Title:           "Pomodoro",
+	Icon:            "🍅",
 	BackgroundColor: "#ba4949",
-	NotifyParams: &notification.NotifyParams{
-		Title:   "Work",
-		Message: "It’s time to focus and make some progress!",
-	},
 }
 
 var BreakSession = Session{
 	SessionType:     Break,
 	Title:           "Short Break",
+	Icon:            "☕",
 	BackgroundColor: "#38858a",
-	NotifyParams: &notification.NotifyParams{
-		Title:   "Short Break",
-		Message: "Take a short break to recharge and reset.",
-	},
 }
 
 var LongBreakSession = Session{
 	SessionType:     LongBreak,
 	Title:           "Long Break",
+	Icon:            "🌴",
 	BackgroundColor: "#397097",
-	NotifyParams: &notification.NotifyParams{
-		Title:   "Long Break",
-		Message: "Enjoy a longer break to fully unwind and refresh.",
-	},
+}
+
+// FlowtimeSession replaces WorkSession in the flowtime mode, where work
+// counts up instead of down.
+var FlowtimeSession = Session{
+	SessionType:     Work,
+	Title:           "Flowtime",
+	Icon:            "🌊",
+	BackgroundColor: "#7a5195",
 }
 
 func Types() []Type {
@@ -59,3 +56,16 @@ func Types() []Type {
 
 	return sessionTypes
 }
+
+func (t Type) Name() string {
+	switch t {
+	case Work:
+		return "work"
+	case Break:
+		return "break"
+	case LongBreak:
+		return "long_break"
+	}
+
+	return ""
+}
@@ -0,0 +1,227 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard/layout"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"strings"
+	"time"
+)
+
+// Duration is written to the config file in a human-editable form
+// like "25m" or "1h30m".
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(formatDuration(time.Duration(d))), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	value, err := time.ParseDuration(strings.TrimSpace(string(text)))
+	if err != nil {
+		return fmt.Errorf("%q is not a duration, use values like \"25m\" or \"1h30m\"", text)
+	}
+
+	*d = Duration(value)
+
+	return nil
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+
+	s := ""
+	if d < 0 {
+		s = "-"
+		d = -d
+	}
+
+	if hours := d / time.Hour; hours > 0 {
+		s += fmt.Sprintf("%dh", hours)
+		d -= hours * time.Hour
+	}
+
+	if minutes := d / time.Minute; minutes > 0 {
+		s += fmt.Sprintf("%dm", minutes)
+		d -= minutes * time.Minute
+	}
+
+	if d > 0 {
+		s += d.String()
+	}
+
+	return s
+}
+
+type sessionDurations struct {
+	Work      Duration `toml:"work"`
+	Break     Duration `toml:"break"`
+	LongBreak Duration `toml:"long_break"`
+}
+
+type sessionToggles struct {
+	Work      bool `toml:"work"`
+	Break     bool `toml:"break"`
+	LongBreak bool `toml:"long_break"`
+}
+
+type sessionStrings struct {
+	Work      string `toml:"work,omitempty"`
+	Break     string `toml:"break,omitempty"`
+	LongBreak string `toml:"long_break,omitempty"`
+}
+
+type fileNotification struct {
+	Sound     bool           `toml:"sound"`
+	Push      bool           `toml:"push"`
+	Tone      config.Tone    `toml:"tone"`
+	SoundFile string         `toml:"sound_file,omitempty"`
+	Messages  sessionStrings `toml:"messages"`
+}
+
+type fileBracket struct {
+	UpTo  Duration `toml:"up_to,omitempty"`
+	Break Duration `toml:"break"`
+}
+
+type fileFlowtime struct {
+	BreakRatio float64       `toml:"break_ratio"`
+	Brackets   []fileBracket `toml:"brackets"`
+}
+
+// fileSettings is the layout of config.toml.
+type fileSettings struct {
+	Version                    int              `toml:"version"`
+	WorkSessionsUntilLongBreak int              `toml:"long_break_interval"`
+	CountSkipped               bool             `toml:"count_skipped"`
+	ShowProgressBar            bool             `toml:"show_progress_bar"`
+	Mouse                      bool             `toml:"mouse"`
+	TerminalTitle              bool             `toml:"terminal_title"`
+	TerminalProgress           bool             `toml:"terminal_progress"`
+	Mode                       config.Mode      `toml:"mode"`
+	Language                   i18n.Language    `toml:"language,omitempty"`
+	KeyboardLayout             layout.Layout    `toml:"keyboard_layout,omitempty"`
+	Durations                  sessionDurations `toml:"durations"`
+	Notification               fileNotification `toml:"notification"`
+	AutoStart                  sessionToggles   `toml:"auto_start"`
+	Overtime                   sessionToggles   `toml:"overtime"`
+	Colors                     sessionStrings   `toml:"colors"`
+	Theme                      config.Theme     `toml:"theme"`
+	Flowtime                   fileFlowtime     `toml:"flowtime"`
+}
+
+func toFileSettings(s config.Settings) fileSettings {
+	brackets := make([]fileBracket, 0, len(s.Flowtime.Brackets))
+	for _, bracket := range s.Flowtime.Brackets {
+		brackets = append(brackets, fileBracket{UpTo: Duration(bracket.UpTo), Break: Duration(bracket.Break)})
+	}
+
+	return fileSettings{
+		Version:                    config.CurrentVersion,
+		WorkSessionsUntilLongBreak: s.WorkSessionsUntilLongBreak,
+		CountSkipped:               s.CountSkipped,
+		ShowProgressBar:            s.ShowProgressBar,
+		Mouse:                      s.Mouse,
+		TerminalTitle:              s.TerminalTitle,
+		TerminalProgress:           s.TerminalProgress,
+		Mode:                       s.Mode,
+		Language:                   s.Language,
+		KeyboardLayout:             s.KeyboardLayout,
+		Theme:                      s.Theme,
+		Flowtime: fileFlowtime{
+			BreakRatio: s.Flowtime.BreakRatio,
+			Brackets:   brackets,
+		},
+		Durations: sessionDurations{
+			Work:      Duration(s.Durations[session.Work]),
+			Break:     Duration(s.Durations[session.Break]),
+			LongBreak: Duration(s.Durations[session.LongBreak]),
+		},
+		Notification: fileNotification{
+			Sound:     s.Notification.Sound,
+			Push:      s.Notification.Push,
+			Tone:      s.Notification.Tone,
+			SoundFile: s.Notification.SoundFile,
+			Messages: sessionStrings{
+				Work:      s.Notification.Messages[session.Work],
+				Break:     s.Notification.Messages[session.Break],
+				LongBreak: s.Notification.Messages[session.LongBreak],
+			},
+		},
+		Colors: sessionStrings{
+			Work:      s.Colors[session.Work],
+			Break:     s.Colors[session.Break],
+			LongBreak: s.Colors[session.LongBreak],
+		},
+		AutoStart: sessionToggles{
+			Work:      s.AutoStart[session.Work],
+			Break:     s.AutoStart[session.Break],
+			LongBreak: s.AutoStart[session.LongBreak],
+		},
+		Overtime: sessionToggles{
+			Work:      s.Overtime[session.Work],
+			Break:     s.Overtime[session.Break],
+			LongBreak: s.Overtime[session.LongBreak],
+		},
+	}
+}
+
+func (f fileSettings) toSettings() config.Settings {
+	brackets := make([]config.Bracket, 0, len(f.Flowtime.Brackets))
+	for _, bracket := range f.Flowtime.Brackets {
+		brackets = append(brackets, config.Bracket{UpTo: time.Duration(bracket.UpTo), Break: time.Duration(bracket.Break)})
+	}
+
+	return config.Settings{
+		Version:                    f.Version,
+		WorkSessionsUntilLongBreak: f.WorkSessionsUntilLongBreak,
+		CountSkipped:               f.CountSkipped,
+		ShowProgressBar:            f.ShowProgressBar,
+		Mouse:                      f.Mouse,
+		TerminalTitle:              f.TerminalTitle,
+		TerminalProgress:           f.TerminalProgress,
+		Mode:                       f.Mode,
+		Language:                   f.Language,
+		KeyboardLayout:             f.KeyboardLayout,
+		Theme:                      f.Theme,
+		Flowtime: config.Flowtime{
+			BreakRatio: f.Flowtime.BreakRatio,
+			Brackets:   brackets,
+		},
+		Durations: config.Durations{
+			session.Work:      time.Duration(f.Durations.Work),
+			session.Break:     time.Duration(f.Durations.Break),
+			session.LongBreak: time.Duration(f.Durations.LongBreak),
+		},
+		Notification: config.Notification{
+			Sound:     f.Notification.Sound,
+			Push:      f.Notification.Push,
+			Tone:      f.Notification.Tone,
+			SoundFile: f.Notification.SoundFile,
+			Messages: config.Messages{
+				session.Work:      f.Notification.Messages.Work,
+				session.Break:     f.Notification.Messages.Break,
+				session.LongBreak: f.Notification.Messages.LongBreak,
+			},
+		},
+		Colors: config.Colors{
+			session.Work:      f.Colors.Work,
+			session.Break:     f.Colors.Break,
+			session.LongBreak: f.Colors.LongBreak,
+		},
+		AutoStart: config.AutoStart{
+			session.Work:      f.AutoStart.Work,
+			session.Break:     f.AutoStart.Break,
+			session.LongBreak: f.AutoStart.LongBreak,
+		},
+		Overtime: config.Overtime{
+			session.Work:      f.Overtime.Work,
+			session.Break:     f.Overtime.Break,
+			session.LongBreak: f.Overtime.LongBreak,
+		},
+	}
+}
@@ -0,0 +1,495 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard/layout"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// field is a setting on the form. load puts the value of the settings into
+// the item and store writes the item back.
+type field struct {
+	item  formItem
+	load  func(s *config.Settings, item *formItem)
+	store func(s *config.Settings, item *formItem)
+	// change runs after the item is edited and may update other items.
+	change func(f form, item *formItem)
+	// derived items show the other items and are loaded again after every
+	// change of the form.
+	derived     bool
+	section     section
+	description string
+}
+
+// form holds an item for each of fields in the same order.
+type form []*formItem
+
+// preset is a set of durations picked with one select.
+type preset struct {
+	name      string
+	durations config.Durations
+}
+
+const customPreset = "custom"
+
+var presets = []preset{
+	{name: "classic 25/5/15", durations: config.Durations{session.Work: 25 * time.Minute, session.Break: 5 * time.Minute, session.LongBreak: 15 * time.Minute}},
+	{name: "extended 50/10/30", durations: config.Durations{session.Work: 50 * time.Minute, session.Break: 10 * time.Minute, session.LongBreak: 30 * time.Minute}},
+	{name: "short 15/3/10", durations: config.Durations{session.Work: 15 * time.Minute, session.Break: 3 * time.Minute, session.LongBreak: 10 * time.Minute}},
+}
+
+var modes = []config.Mode{config.ClassicMode, config.FlowtimeMode}
+
+// sessionTitles name the sessions in item titles.
+var sessionTitles = map[session.Type]string{
+	session.Work:      "Pomodoro",
+	session.Break:     "Break",
+	session.LongBreak: "Long Break",
+}
+
+// section groups fields on the settings page.
+type section string
+
+const (
+	timerSection         section = "Timer"
+	cycleSection         section = "Cycle"
+	notificationsSection section = "Notifications"
+	soundSection         section = "Sound"
+	appearanceSection    section = "Appearance"
+	keyboardSection      section = "Keyboard"
+	integrationsSection  section = "Integrations"
+)
+
+// Title is the name of the section in the language of the UI.
+func (s section) Title() string {
+	return i18n.T(string(s))
+}
+
+// sections are shown in this order, sections without fields are hidden.
+var sections = []section{
+	timerSection,
+	cycleSection,
+	notificationsSection,
+	soundSection,
+	appearanceSection,
+	keyboardSection,
+	integrationsSection,
+}
+
+var fields = []field{
+	presetField().
+		describe(timerSection, "Sets the durations of all sessions at once, custom when they match no preset."),
+	durationField(session.Work).
+		describe(timerSection, "Length of a work session, e.g. 25m or 1h30m."),
+	durationField(session.Break).
+		describe(timerSection, "Length of a short break."),
+	durationField(session.LongBreak).
+		describe(timerSection, "Length of the break after a full cycle of work sessions."),
+	modeField().
+		describe(timerSection, "Classic counts every session down. Flowtime counts work up until you finish it with n and computes the break from the time worked."),
+	ratioField().
+		describe(timerSection, "Flowtime break as a percentage of the time worked. None uses the brackets of the config file."),
+	sessionToggleField("Overtime", func(s *config.Settings) map[session.Type]bool { return s.Overtime }, session.Work).
+		describe(timerSection, "Keep counting past the end of a work session, the next session starts when you press n."),
+	sessionToggleField("Overtime", func(s *config.Settings) map[session.Type]bool { return s.Overtime }, session.Break).
+		describe(timerSection, "Keep counting past the end of a short break."),
+	sessionToggleField("Overtime", func(s *config.Settings) map[session.Type]bool { return s.Overtime }, session.LongBreak).
+		describe(timerSection, "Keep counting past the end of a long break."),
+	intervalField().
+		describe(cycleSection, "Work sessions before a long break, None for no long breaks."),
+	toggleField("Count skipped sessions", func(s *config.Settings) *bool { return &s.CountSkipped }).
+		describe(cycleSection, "Count work sessions skipped with n toward the long break."),
+	sessionToggleField("Auto start", func(s *config.Settings) map[session.Type]bool { return s.AutoStart }, session.Work).
+		describe(cycleSection, "Start a work session as soon as the break before it ends."),
+	sessionToggleField("Auto start", func(s *config.Settings) map[session.Type]bool { return s.AutoStart }, session.Break).
+		describe(cycleSection, "Start a short break as soon as the work before it ends."),
+	sessionToggleField("Auto start", func(s *config.Settings) map[session.Type]bool { return s.AutoStart }, session.LongBreak).
+		describe(cycleSection, "Start a long break as soon as the work before it ends."),
+	toggleField("Push notification", func(s *config.Settings) *bool { return &s.Notification.Push }).
+		describe(notificationsSection, "Show a desktop notification when a session ends."),
+	messageField(session.Work).
+		describe(notificationsSection, "Text of the notification that a work session starts, empty for the built-in one."),
+	messageField(session.Break).
+		describe(notificationsSection, "Text of the notification that a short break starts."),
+	messageField(session.LongBreak).
+		describe(notificationsSection, "Text of the notification that a long break starts."),
+	toggleField("Sound notification", func(s *config.Settings) *bool { return &s.Notification.Sound }).
+		describe(soundSection, "Play a sound when a session ends."),
+	toneField().
+		describe(soundSection, "The built-in sound: a ring, a bell or three beeps."),
+	soundFileField().
+		describe(soundSection, "An mp3 file to play instead of the built-in sound, tab completes the path."),
+	toggleField("Show progress bar", func(s *config.Settings) *bool { return &s.ShowProgressBar }).
+		describe(appearanceSection, "Show the progress of the session under the clock."),
+	toggleField("Mouse", func(s *config.Settings) *bool { return &s.Mouse }).
+		describe(appearanceSection, "Click the tabs, the clock and the progress bar, scroll over the clock to change the time and click settings."),
+	themeField().
+		describe(appearanceSection, "Colors of the session tabs and progress bars, the colors below replace single ones."),
+	colorField(session.Work).
+		describe(appearanceSection, "Color of the work tab and progress bar, left and right go through a palette."),
+	colorField(session.Break).
+		describe(appearanceSection, "Color of the short break tab and progress bar."),
+	colorField(session.LongBreak).
+		describe(appearanceSection, "Color of the long break tab and progress bar."),
+	languageField().
+		describe(appearanceSection, "Language of the texts, auto follows the locale (LANG)."),
+	layoutField().
+		describe(keyboardSection, "Keys are where they are on QWERTY in the chosen layout, auto maps the letters of Cyrillic and Greek layouts."),
+	toggleField("Terminal title", func(s *config.Settings) *bool { return &s.TerminalTitle }).
+		describe(integrationsSection, "Show the time and the session in the title of the terminal window, the title is restored on exit."),
+	toggleField("Terminal progress", func(s *config.Settings) *bool { return &s.TerminalProgress }).
+		describe(integrationsSection, "Show the progress in the taskbar button or tab of Windows Terminal, Ghostty, ConEmu or WezTerm. Other terminals may show it as a notification."),
+}
+
+func (f field) describe(s section, description string) field {
+	f.section = s
+	f.description = description
+
+	return f
+}
+
+func intervalField() field {
+	return field{
+		item: formItem{title: "Long Break interval", kind: numberItem, limits: &limits{min: 0, max: maxLimit}},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = s.WorkSessionsUntilLongBreak
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.WorkSessionsUntilLongBreak = item.value
+		},
+	}
+}
+
+func soundFileField() field {
+	return field{
+		item: formItem{title: "Sound file (mp3)", kind: pathItem, validate: validateSoundFile},
+		load: func(s *config.Settings, item *formItem) {
+			item.text = s.Notification.SoundFile
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.Notification.SoundFile = item.text
+		},
+	}
+}
+
+func modeField() field {
+	return field{
+		item: formItem{title: "Mode", kind: selectItem, options: []string{string(config.ClassicMode), string(config.FlowtimeMode)}},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = 0
+			for index, mode := range modes {
+				if mode == s.Mode {
+					item.value = index
+				}
+			}
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.Mode = modes[item.value]
+		},
+	}
+}
+
+func themeField() field {
+	return field{
+		item: formItem{title: "Theme", kind: selectItem, options: themeValues()},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = 0
+			for index, theme := range config.Themes {
+				if theme == s.Theme {
+					item.value = index
+				}
+			}
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.Theme = config.Themes[item.value]
+		},
+	}
+}
+
+func toneField() field {
+	return field{
+		item: formItem{title: "Built-in sound", kind: selectItem, options: toneValues()},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = 0
+			for index, tone := range config.Tones {
+				if tone == s.Notification.Tone {
+					item.value = index
+				}
+			}
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.Notification.Tone = config.Tones[item.value]
+		},
+	}
+}
+
+// languageField offers auto and the languages by their own names.
+func languageField() field {
+	options := []string{"auto"}
+	for _, language := range i18n.Languages {
+		options = append(options, i18n.Name(language))
+	}
+
+	return field{
+		item: formItem{title: "Language", kind: selectItem, options: options},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = 0
+			for index, language := range i18n.Languages {
+				if language == s.Language {
+					item.value = index + 1
+				}
+			}
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.Language = i18n.Auto
+			if item.value > 0 {
+				s.Language = i18n.Languages[item.value-1]
+			}
+		},
+	}
+}
+
+// layoutField offers auto and the keyboard layouts.
+func layoutField() field {
+	options := []string{"auto"}
+	for _, l := range layout.Layouts {
+		options = append(options, layout.Name(l))
+	}
+
+	return field{
+		item: formItem{title: "Keyboard layout", kind: selectItem, options: options},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = 0
+			for index, l := range layout.Layouts {
+				if l == s.KeyboardLayout {
+					item.value = index + 1
+				}
+			}
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.KeyboardLayout = layout.Auto
+			if item.value > 0 {
+				s.KeyboardLayout = layout.Layouts[item.value-1]
+			}
+		},
+	}
+}
+
+func ratioField() field {
+	return field{
+		item: formItem{title: "% Flowtime break of work (None: brackets)", kind: numberItem, limits: &limits{min: 0, max: 100}},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = int(math.Round(s.Flowtime.BreakRatio * 100))
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.Flowtime.BreakRatio = float64(item.value) / 100
+		},
+	}
+}
+
+func toggleField(title string, get func(s *config.Settings) *bool) field {
+	return field{
+		item: formItem{title: title, kind: toggleItem},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = toInt(*get(s))
+		},
+		store: func(s *config.Settings, item *formItem) {
+			*get(s) = toBool(item.value)
+		},
+	}
+}
+
+func sessionToggleField(title string, get func(s *config.Settings) map[session.Type]bool, sessionType session.Type) field {
+	return field{
+		item: formItem{title: fmt.Sprintf("%s: %s", title, sessionTitles[sessionType]), kind: toggleItem},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = toInt(get(s)[sessionType])
+		},
+		store: func(s *config.Settings, item *formItem) {
+			get(s)[sessionType] = toBool(item.value)
+		},
+	}
+}
+
+func durationField(sessionType session.Type) field {
+	return field{
+		item: formItem{title: fmt.Sprintf("duration: %s", sessionTitles[sessionType]), kind: durationItem, validate: validateDuration, session: sessionType},
+		load: func(s *config.Settings, item *formItem) {
+			item.text = formatDuration(s.Durations[sessionType])
+		},
+		store: func(s *config.Settings, item *formItem) {
+			value, err := time.ParseDuration(item.text)
+			if err == nil {
+				s.Durations[sessionType] = value
+			}
+		},
+	}
+}
+
+func messageField(sessionType session.Type) field {
+	return field{
+		item: formItem{title: fmt.Sprintf("message: %s", sessionTitles[sessionType]), kind: textItem},
+		load: func(s *config.Settings, item *formItem) {
+			item.text = s.Notification.Messages[sessionType]
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.Notification.Messages[sessionType] = item.text
+		},
+	}
+}
+
+func colorField(sessionType session.Type) field {
+	return field{
+		item: formItem{title: fmt.Sprintf("color: %s", sessionTitles[sessionType]), kind: colorItem, validate: validateColor},
+		load: func(s *config.Settings, item *formItem) {
+			item.text = s.Colors[sessionType]
+		},
+		store: func(s *config.Settings, item *formItem) {
+			s.Colors[sessionType] = item.text
+		},
+	}
+}
+
+// presetField sets the durations of a preset and shows which preset the
+// durations match.
+func presetField() field {
+	options := []string{customPreset}
+	for _, p := range presets {
+		options = append(options, p.name)
+	}
+
+	return field{
+		item: formItem{title: "Durations preset", kind: selectItem, options: options},
+		load: func(s *config.Settings, item *formItem) {
+			item.value = 0
+			for index, p := range presets {
+				if sameDurations(p.durations, s.Durations) {
+					item.value = index + 1
+				}
+			}
+		},
+		store: func(*config.Settings, *formItem) {},
+		change: func(f form, item *formItem) {
+			if item.value == 0 {
+				return
+			}
+
+			// the duration items follow the preset
+			for _, duration := range f {
+				if duration.kind == durationItem {
+					duration.text = formatDuration(presets[item.value-1].durations[duration.session])
+				}
+			}
+		},
+		derived: true,
+	}
+}
+
+func sameDurations(a, b config.Durations) bool {
+	for _, sessionType := range session.Types() {
+		if a[sessionType] != b[sessionType] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validateDuration(text string) error {
+	value, err := time.ParseDuration(text)
+	if err != nil {
+		return errors.New(i18n.T("%q is not a duration, use values like \"25m\" or \"1h30m\"", text))
+	}
+
+	if value < time.Minute {
+		return errors.New(i18n.T("must be at least 1m"))
+	}
+
+	return nil
+}
+
+func validateColor(text string) error {
+	if text != "" && !config.IsColor(text) {
+		return errors.New(i18n.T("use a color like #ba4949, or nothing for the default"))
+	}
+
+	return nil
+}
+
+func validateSoundFile(text string) error {
+	if text == "" {
+		return nil
+	}
+
+	if !strings.EqualFold(filepath.Ext(text), ".mp3") {
+		return errors.New(i18n.T("only mp3 files can be played"))
+	}
+
+	info, err := os.Stat(ExpandPath(text))
+	if err != nil {
+		return errors.New(i18n.T("no such file"))
+	}
+
+	if info.IsDir() {
+		return errors.New(i18n.T("%s is a directory", text))
+	}
+
+	return nil
+}
+
+// ExpandPath replaces a leading ~ with the home directory.
+func ExpandPath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	return filepath.Join(home, path[2:])
+}
+
+func newForm(s *config.Settings) form {
+	f := make(form, len(fields))
+
+	for index, field := range fields {
+		item := field.item
+		field.load(s, &item)
+		f[index] = &item
+	}
+
+	return f
+}
+
+// apply writes the form over base, settings without an item such as the
+// flowtime brackets are kept from it.
+func (f form) apply(base *config.Settings) config.Settings {
+	s := base.Clone()
+
+	for index, field := range fields {
+		field.store(&s, f[index])
+	}
+
+	return s
+}
+
+// changed runs after the item at index was edited.
+func (f form) changed(index int, base *config.Settings) {
+	if change := fields[index].change; change != nil {
+		change(f, f[index])
+	}
+
+	s := f.apply(base)
+
+	for index, field := range fields {
+		if field.derived {
+			field.load(&s, f[index])
+		}
+	}
+}
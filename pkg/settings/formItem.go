@@ -1,29 +1,68 @@
 package settings
 
 import (
+	"errors"
 	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"github.com/borissimkin/pomogoro/pkg/settings/keybinding"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 const (
-	toggleItem kindFormItem = "toggle"
-	numberItem kindFormItem = "number"
+	toggleItem   kindFormItem = "toggle"
+	numberItem   kindFormItem = "number"
+	durationItem kindFormItem = "duration"
+	textItem     kindFormItem = "text"
+	selectItem   kindFormItem = "select"
+	pathItem     kindFormItem = "path"
+	colorItem    kindFormItem = "color"
 )
 
+const durationStep = time.Minute
+
 var (
 	onStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#00FF00"))
 	offStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FF0000"))
+	valueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#87CEEB"))
 )
 
+// palette is what left and right go through on a color item, the empty
+// color is the default of the session.
+var palette = []string{"", "#ba4949", "#38858a", "#397097", "#7a5195", "#d45087", "#e8a33d", "#4c9a2a", "#444e86"}
+
 type kindFormItem string
 
+// formItem is a widget of the settings form. Toggles, numbers and selects
+// keep their value in value, the other kinds in text.
 type formItem struct {
-	title  string
-	value  int
-	kind   kindFormItem
-	limits *limits
+	title   string
+	value   int
+	text    string
+	kind    kindFormItem
+	limits  *limits
+	options []string
+	// validate checks typed text before it is accepted, the error is
+	// shown under the item.
+	validate func(text string) error
+	err      error
+	editing  bool
+	input    textinput.Model
+	keymap   keybinding.InputKeyMap
+	// session is the session a duration item sets the length of.
+	session session.Type
 }
 
 func (item *formItem) isToggle() bool {
@@ -34,77 +73,300 @@ func (item *formItem) isNumber() bool {
 	return item.kind == numberItem
 }
 
-func (item *formItem) Enter() {
-	if !item.isToggle() {
-		return
+// isTyped reports whether the value of the item can be typed in.
+func (item *formItem) isTyped() bool {
+	switch item.kind {
+	case numberItem, durationItem, textItem, pathItem, colorItem:
+		return true
 	}
 
-	if item.value >= 1 {
-		item.value = 0
-	} else {
-		item.value = 1
+	return false
+}
+
+// Enter toggles a toggle, goes to the next option of a select and starts
+// typing into the other kinds.
+func (item *formItem) Enter() tea.Cmd {
+	switch {
+	case item.isToggle():
+		if item.value >= 1 {
+			item.value = 0
+		} else {
+			item.value = 1
+		}
+	case item.kind == selectItem:
+		item.value = (item.value + 1) % len(item.options)
+	case item.isTyped():
+		return item.startEditing()
 	}
+
+	return nil
 }
 
 func (item *formItem) Increase() {
-	if item.isToggle() {
+	switch item.kind {
+	case toggleItem:
 		item.value = 1
-		return
+	case numberItem:
+		item.setNumber(item.value + 1)
+	case durationItem:
+		item.stepDuration(durationStep)
+	case selectItem:
+		item.value = (item.value + 1) % len(item.options)
+	case colorItem:
+		item.stepColor(1)
 	}
+}
 
-	value := item.value + 1
+func (item *formItem) Decrease() {
+	switch item.kind {
+	case toggleItem:
+		item.value = 0
+	case numberItem:
+		item.setNumber(item.value - 1)
+	case durationItem:
+		item.stepDuration(-durationStep)
+	case selectItem:
+		item.value = (item.value + len(item.options) - 1) % len(item.options)
+	case colorItem:
+		item.stepColor(-1)
+	}
+}
 
-	if item.limits != nil && item.limits.max < value {
+func (item *formItem) setNumber(value int) {
+	if item.limits != nil && (item.limits.min > value || item.limits.max < value) {
 		return
 	}
 
 	item.value = value
 }
 
-func (item *formItem) Decrease() {
-	if item.isToggle() {
-		item.value = 0
+func (item *formItem) stepDuration(step time.Duration) {
+	value, err := time.ParseDuration(item.text)
+	if err != nil {
+		return
 	}
 
-	value := item.value - 1
+	value = (value + step).Truncate(durationStep)
+	if value < time.Minute {
+		return
+	}
+
+	item.text = formatDuration(value)
+}
 
-	if item.limits != nil && item.limits.min > value {
+func (item *formItem) stepColor(step int) {
+	index := 0
+	for i, color := range palette {
+		if strings.EqualFold(color, item.text) {
+			index = i
+		}
+	}
+
+	item.text = palette[(index+step+len(palette))%len(palette)]
+}
+
+func (item *formItem) startEditing() tea.Cmd {
+	item.editing = true
+	item.err = nil
+	item.keymap = keybinding.InitInputKeys()
+	item.keymap.Complete.SetEnabled(item.kind == pathItem)
+
+	item.input = textinput.New()
+	item.input.Prompt = ""
+	item.input.SetValue(item.editValue())
+	item.input.CursorEnd()
+
+	return item.input.Focus()
+}
+
+func (item *formItem) editValue() string {
+	if item.isNumber() {
+		return strconv.Itoa(item.value)
+	}
+
+	return item.text
+}
+
+// update handles a message while the item is being typed into.
+func (item *formItem) update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case keyboard.Matches(msg, item.keymap.Confirm):
+			item.commit()
+			return nil
+		case keyboard.Matches(msg, item.keymap.Cancel):
+			item.editing = false
+			item.err = nil
+			return nil
+		case keyboard.Matches(msg, item.keymap.Complete):
+			item.input.SetValue(completePath(item.input.Value()))
+			item.input.CursorEnd()
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	item.input, cmd = item.input.Update(msg)
+
+	return cmd
+}
+
+// commit accepts the typed text if it is valid, otherwise the item stays
+// in typing with the error shown.
+func (item *formItem) commit() {
+	text := strings.TrimSpace(item.input.Value())
+
+	item.err = item.check(text)
+	if item.err != nil {
 		return
 	}
 
-	item.value = value
+	switch item.kind {
+	case numberItem:
+		item.value, _ = strconv.Atoi(text)
+	case durationItem:
+		value, _ := time.ParseDuration(text)
+		item.text = formatDuration(value)
+	default:
+		item.text = text
+	}
+
+	item.editing = false
+}
+
+func (item *formItem) check(text string) error {
+	if item.isNumber() {
+		value, err := strconv.Atoi(text)
+		if err != nil {
+			return errors.New(i18n.T("%q is not a number", text))
+		}
+
+		if item.limits != nil && (item.limits.min > value || item.limits.max < value) {
+			return errors.New(i18n.T("must be from %v to %v", item.limits.min, item.limits.max))
+		}
+	}
+
+	if item.validate != nil {
+		return item.validate(text)
+	}
+
+	return nil
+}
+
+// completePath completes the last element of path to the longest prefix
+// shared by the files it matches. Only the directory is expanded to read
+// it, the typed part is kept as it is, e.g. with its ~.
+func completePath(path string) string {
+	dir, base := filepath.Split(path)
+
+	entries, err := os.ReadDir(dirOrCurrent(ExpandPath(dir)))
+	if err != nil {
+		return path
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), base) {
+			name := entry.Name()
+			if entry.IsDir() {
+				name += string(filepath.Separator)
+			}
+
+			matches = append(matches, name)
+		}
+	}
+
+	if len(matches) == 0 {
+		return path
+	}
+
+	prefix := matches[0]
+	for _, match := range matches[1:] {
+		// names are trimmed by runes, a byte of a multibyte letter is not
+		// a valid prefix to type in
+		for !strings.HasPrefix(match, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+
+	return dir + prefix
+}
+
+func dirOrCurrent(dir string) string {
+	if dir == "" {
+		return "."
+	}
+
+	return dir
+}
+
+// Title is the title in the language of the UI.
+func (item *formItem) Title() string {
+	return i18n.T(item.title)
 }
 
 func (item *formItem) View() string {
-	if item.isToggle() {
-		return item.toggleItemView()
+	var s string
+
+	switch {
+	case item.editing:
+		s = fmt.Sprintf("%s %s", item.input.View(), item.Title())
+	case item.isToggle():
+		s = item.toggleItemView()
+	case item.isNumber():
+		s = item.numberItemView()
+	case item.kind == selectItem:
+		s = fmt.Sprintf("‹%s› %s", valueStyle.Render(i18n.T(item.options[item.value])), item.Title())
+	case item.kind == colorItem:
+		s = item.colorItemView()
+	default:
+		s = item.textItemView()
 	}
 
-	if item.isNumber() {
-		return item.numberItemView()
+	if item.err != nil {
+		s += "\n    " + errorStyle.Render(item.err.Error())
 	}
 
-	return ""
+	return s
 }
 
 func (item *formItem) toggleItemView() string {
 	s := ""
 
-	value := offStyle.Render("off")
+	value := offStyle.Render(i18n.T("off"))
 
 	if item.value == 1 {
-		value = onStyle.Render("on")
+		value = onStyle.Render(i18n.T("on"))
 	}
 
-	s += fmt.Sprintf("%s %s", value, item.title)
+	s += fmt.Sprintf("%s %s", value, item.Title())
 
 	return s
 }
 
 func (item *formItem) numberItemView() string {
 	if item.value <= 0 {
-		return fmt.Sprintf("%s %s", offStyle.Render("None"), item.title)
+		return fmt.Sprintf("%s %s", offStyle.Render(i18n.T("None")), item.Title())
+	}
+
+	return fmt.Sprintf("%v %s", item.value, item.Title())
+}
+
+func (item *formItem) textItemView() string {
+	if item.text == "" {
+		return fmt.Sprintf("%s %s", offStyle.Render(i18n.T("default")), item.Title())
 	}
 
-	return fmt.Sprintf("%v %s", item.value, item.title)
+	return fmt.Sprintf("%s %s", valueStyle.Render(item.text), item.Title())
+}
+
+func (item *formItem) colorItemView() string {
+	if item.text == "" {
+		return fmt.Sprintf("%s %s", offStyle.Render(i18n.T("default")), item.Title())
+	}
+
+	swatch := lipgloss.NewStyle().Foreground(lipgloss.Color(item.text)).Render("██")
+
+	return fmt.Sprintf("%s %s %s", swatch, item.text, item.Title())
 }
@@ -0,0 +1,43 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCompletePath(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"звонок.mp3", "звонб.mp3", "bell.mp3"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "Music"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	dir += string(filepath.Separator)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: dir + "b", want: dir + "bell.mp3"},
+		{path: dir + "M", want: dir + "Music" + string(filepath.Separator)},
+		// "о" and "б" differ only in their second byte
+		{path: dir + "з", want: dir + "звон"},
+		{path: dir + "x", want: dir + "x"},
+	}
+
+	for _, test := range tests {
+		got := completePath(test.path)
+		if got != test.want {
+			t.Errorf("completePath(%q) = %q, want %q", test.path, got, test.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("completePath(%q) = %q is not valid UTF-8", test.path, got)
+		}
+	}
+}
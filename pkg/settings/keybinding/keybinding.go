@@ -1,30 +1,57 @@
 package keybinding
 
 import (
+	"github.com/borissimkin/pomogoro/pkg/i18n"
 	"github.com/charmbracelet/bubbles/key"
 )
 
 type KeyMap struct {
-	Help  key.Binding
-	Reset key.Binding
-	Enter key.Binding
-	Back  key.Binding
-	Up    key.Binding
-	Down  key.Binding
-	Left  key.Binding
-	Right key.Binding
-	Quit  key.Binding
+	Help        key.Binding
+	Command     key.Binding
+	Filter      key.Binding
+	NextSection key.Binding
+	PrevSection key.Binding
+	Reset       key.Binding
+	ResetItem   key.Binding
+	Save        key.Binding
+	Enter       key.Binding
+	Back        key.Binding
+	Up          key.Binding
+	Down        key.Binding
+	Left        key.Binding
+	Right       key.Binding
+	Quit        key.Binding
+}
+
+// InputKeyMap is used while a value is typed into a form item.
+type InputKeyMap struct {
+	Confirm  key.Binding
+	Cancel   key.Binding
+	Complete key.Binding
+}
+
+func (k InputKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Confirm, k.Cancel, k.Complete}
+}
+
+func (k InputKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
 		k.Help,
 		k.Enter,
+		k.Command,
+		k.NextSection,
+		k.Filter,
+		k.Save,
 		k.Back,
 		k.Left,
 		k.Right,
 		k.Up,
 		k.Down,
+		k.ResetItem,
 		k.Reset,
 		k.Quit,
 	}
@@ -33,46 +60,87 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
-		{k.Enter, k.Reset, k.Back, k.Help, k.Quit},
+		{k.NextSection, k.PrevSection, k.Filter},
+		{k.Enter, k.ResetItem, k.Reset},
+		{k.Save, k.Back, k.Help, k.Quit, k.Command},
 	}
 }
 
 func InitKeys() KeyMap {
 	return KeyMap{
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", i18n.T("command")),
+		),
 		Reset: key.NewBinding(
-			key.WithKeys("r", "к"),
-			key.WithHelp("r", "reset to defaults")),
+			key.WithKeys("r"),
+			key.WithHelp("r", i18n.T("reset all to defaults"))),
+		ResetItem: key.NewBinding(
+			key.WithKeys("x", "backspace", "delete"),
+			key.WithHelp("x", i18n.T("reset to default"))),
+		Save: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", i18n.T("save"))),
 		Enter: key.NewBinding(
 			key.WithKeys("enter", " "),
-			key.WithHelp("space", "toggle"),
+			key.WithHelp("enter", i18n.T("toggle/edit")),
 		),
 		Back: key.NewBinding(
-			key.WithKeys("esc", "b", "и"),
-			key.WithHelp("esc/b", "back"),
+			key.WithKeys("esc", "b"),
+			key.WithHelp("esc/b", i18n.T("back")),
 		),
 		Quit: key.NewBinding(
-			key.WithKeys("q", "й", "ctrl+c"),
-			key.WithHelp("q", "quit"),
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", i18n.T("quit")),
 		),
 		Up: key.NewBinding(
-			key.WithKeys("up", "k", "л", "w", "ц"),
-			key.WithHelp("↑/w/k", "move up"),
+			key.WithKeys("up", "k", "w"),
+			key.WithHelp("↑/w/k", i18n.T("move up")),
 		),
 		Down: key.NewBinding(
-			key.WithKeys("down", "j", "о", "s", "ы"),
-			key.WithHelp("↓/s/j", "move down"),
+			key.WithKeys("down", "j", "s"),
+			key.WithHelp("↓/s/j", i18n.T("move down")),
 		),
 		Left: key.NewBinding(
-			key.WithKeys("left", "h", "р", "a", "ф"),
-			key.WithHelp("←/a/h", "decrease"),
+			key.WithKeys("left", "h", "a"),
+			key.WithHelp("←/a/h", i18n.T("decrease")),
 		),
 		Right: key.NewBinding(
-			key.WithKeys("right", "l", "д", "d", "в"),
-			key.WithHelp("→/d/l", "increase"),
+			key.WithKeys("right", "l", "d"),
+			key.WithHelp("→/d/l", i18n.T("increase")),
 		),
 		Help: key.NewBinding(
-			key.WithKeys("/", "?"),
-			key.WithHelp("?", "help"),
+			key.WithKeys("?"),
+			key.WithHelp("?", i18n.T("help")),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", i18n.T("filter")),
+		),
+		NextSection: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", i18n.T("next section")),
+		),
+		PrevSection: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", i18n.T("previous section")),
+		),
+	}
+}
+
+func InitInputKeys() InputKeyMap {
+	return InputKeyMap{
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", i18n.T("confirm")),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", i18n.T("cancel")),
+		),
+		Complete: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", i18n.T("complete path")),
 		),
 	}
 }
@@ -0,0 +1,173 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"strconv"
+	"time"
+)
+
+const (
+	versionKey       = "version"
+	legacyVersionKey = "Version"
+)
+
+// migration upgrades a raw settings document by one version.
+type migration func(doc map[string]any) error
+
+// migrations[i] upgrades a document from version i+1 to version i+2.
+var migrations = []migration{
+	migrateV1,
+	migrateV2,
+	migrateV3,
+}
+
+func getVersion(doc map[string]any) (int, error) {
+	raw, ok := doc[versionKey]
+	if !ok {
+		raw, ok = doc[legacyVersionKey]
+	}
+	if !ok {
+		return 1, nil
+	}
+
+	var version int
+
+	switch value := raw.(type) {
+	case int64:
+		version = int(value)
+	case float64:
+		version = int(value)
+		if value != float64(version) {
+			version = 0
+		}
+	}
+
+	if version < 1 {
+		return 0, fmt.Errorf("settings version %v is not valid", raw)
+	}
+
+	return version, nil
+}
+
+func migrate(doc map[string]any) error {
+	version, err := getVersion(doc)
+	if err != nil {
+		return err
+	}
+
+	if version > config.CurrentVersion {
+		return fmt.Errorf("settings version %v is newer than supported %v, update pomogoro", version, config.CurrentVersion)
+	}
+
+	for ; version < config.CurrentVersion; version++ {
+		err = migrations[version-1](doc)
+		if err != nil {
+			return fmt.Errorf("migrate settings from version %v: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+// migrateV1 fills the fields missing in unversioned files with defaults.
+func migrateV1(doc map[string]any) error {
+	defaults := map[string]any{
+		"WorkSessionsUntilLongBreak": 4,
+		"ShowProgressBar":            true,
+		"Notification":               map[string]any{"Sound": true, "Push": true},
+		"AutoStart":                  map[string]any{"1": true, "2": true, "3": true},
+		"Durations": map[string]any{
+			"1": float64(25 * time.Minute),
+			"2": float64(5 * time.Minute),
+			"3": float64(15 * time.Minute),
+		},
+	}
+
+	for key, value := range defaults {
+		if _, ok := doc[key]; !ok {
+			doc[key] = value
+		}
+	}
+
+	doc[legacyVersionKey] = 2
+
+	return nil
+}
+
+// migrateV2 converts settings.json to the config.toml layout: snake case
+// keys, session names instead of numbers and durations as strings.
+func migrateV2(doc map[string]any) error {
+	var legacy struct {
+		WorkSessionsUntilLongBreak int
+		ShowProgressBar            bool
+		Durations                  map[string]time.Duration
+		Notification               map[string]bool
+		AutoStart                  map[string]bool
+	}
+
+	bytes, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(bytes, &legacy)
+	if err != nil {
+		return err
+	}
+
+	durations := make(map[string]any)
+	autoStart := make(map[string]any)
+
+	for _, sessionType := range session.Types() {
+		key := strconv.Itoa(int(sessionType))
+
+		if duration, ok := legacy.Durations[key]; ok {
+			durations[sessionType.Name()] = formatDuration(duration)
+		}
+
+		if value, ok := legacy.AutoStart[key]; ok {
+			autoStart[sessionType.Name()] = value
+		}
+	}
+
+	for key := range doc {
+		delete(doc, key)
+	}
+
+	doc[versionKey] = int64(3)
+	doc["long_break_interval"] = int64(legacy.WorkSessionsUntilLongBreak)
+	doc["show_progress_bar"] = legacy.ShowProgressBar
+	doc["durations"] = durations
+	doc["auto_start"] = autoStart
+	doc["notification"] = map[string]any{
+		"sound": legacy.Notification["Sound"],
+		"push":  legacy.Notification["Push"],
+	}
+
+	return nil
+}
+
+// migrateV3 adds the theme and the built-in sound, files written before
+// they existed keep the colors and the ring they had.
+func migrateV3(doc map[string]any) error {
+	if _, ok := doc["theme"]; !ok {
+		doc["theme"] = string(config.TomatoTheme)
+	}
+
+	notification, ok := doc["notification"].(map[string]any)
+	if !ok {
+		notification = make(map[string]any)
+		doc["notification"] = notification
+	}
+
+	if _, ok := notification["tone"]; !ok {
+		notification["tone"] = string(config.RingTone)
+	}
+
+	doc[versionKey] = int64(4)
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package settings
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     map[string]any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "unversioned settings.json",
+			doc:  map[string]any{},
+			want: map[string]any{
+				"version":             int64(4),
+				"long_break_interval": int64(4),
+				"show_progress_bar":   true,
+				"theme":               "tomato",
+				"durations":           map[string]any{"work": "25m", "break": "5m", "long_break": "15m"},
+				"auto_start":          map[string]any{"work": true, "break": true, "long_break": true},
+				"notification":        map[string]any{"sound": true, "push": true, "tone": "ring"},
+			},
+		},
+		{
+			name: "settings.json of version 2",
+			doc: map[string]any{
+				"Version":                    float64(2),
+				"WorkSessionsUntilLongBreak": float64(3),
+				"ShowProgressBar":            false,
+				"Durations":                  map[string]any{"1": float64(50 * time.Minute), "3": float64(90 * time.Minute)},
+				"AutoStart":                  map[string]any{"2": false},
+				"Notification":               map[string]any{"Sound": false, "Push": true},
+			},
+			want: map[string]any{
+				"version":             int64(4),
+				"long_break_interval": int64(3),
+				"show_progress_bar":   false,
+				"theme":               "tomato",
+				"durations":           map[string]any{"work": "50m", "long_break": "1h30m"},
+				"auto_start":          map[string]any{"break": false},
+				"notification":        map[string]any{"sound": false, "push": true, "tone": "ring"},
+			},
+		},
+		{
+			name: "config.toml of version 3",
+			doc: map[string]any{
+				"version":      int64(3),
+				"theme":        "ocean",
+				"notification": map[string]any{"sound": true},
+			},
+			want: map[string]any{
+				"version":      int64(4),
+				"theme":        "ocean",
+				"notification": map[string]any{"sound": true, "tone": "ring"},
+			},
+		},
+		{
+			name: "current version",
+			doc:  map[string]any{"version": int64(4), "theme": "mono"},
+			want: map[string]any{"version": int64(4), "theme": "mono"},
+		},
+		{name: "newer version", doc: map[string]any{"version": int64(5)}, wantErr: true},
+		{name: "fractional version", doc: map[string]any{"Version": 1.5}, wantErr: true},
+		{name: "version as a string", doc: map[string]any{"version": "3"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := migrate(test.doc)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("migrate() error = %v, want error %v", err, test.wantErr)
+			}
+
+			if !test.wantErr && !reflect.DeepEqual(test.doc, test.want) {
+				t.Errorf("migrate() = %v, want %v", test.doc, test.want)
+			}
+		})
+	}
+}
@@ -1,50 +1,42 @@
 package settings
 
 import (
-	"fmt"
-	"github.com/borissimkin/pomogoro/pkg/app"
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
+	"github.com/borissimkin/pomogoro/pkg/profile"
 	"github.com/borissimkin/pomogoro/pkg/router"
-	"github.com/borissimkin/pomogoro/pkg/session"
 	"github.com/borissimkin/pomogoro/pkg/settings/keybinding"
 	"github.com/charmbracelet/bubbles/help"
-	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"time"
+	"reflect"
+	"strings"
 )
 
 var (
 	settingsStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
-		MarginLeft(2).
-		PaddingLeft(1).
-		PaddingRight(1)
+			Foreground(lipgloss.Color("229")).
+			Background(lipgloss.Color("57")).
+			MarginLeft(2).
+			PaddingLeft(1).
+			PaddingRight(1)
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF0000"))
+	overriddenStyle = lipgloss.NewStyle().
+			Faint(true)
+	changedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFA500"))
 )
 
-const (
-	minLimit = 1
-	maxLimit = 9999
-)
+const maxLimit = 9999
 
 type limits struct {
 	min int
 	max int
 }
 
-type formMap struct {
-	workMinutes                 *formItem
-	breakMinutes                *formItem
-	longBreakMinutes            *formItem
-	workSessionsBeforeLongBreak *formItem
-	workAutoStart               *formItem
-	breakAutoStart              *formItem
-	longBreakAutoStart          *formItem
-	soundNotification           *formItem
-	pushNotification            *formItem
-	showProgressBar             *formItem
-}
-
 func toInt(v bool) int {
 	if v {
 		return 1
@@ -57,189 +49,281 @@ func toBool(v int) bool {
 	return v == 1
 }
 
-func initFormMap(settings *Settings) formMap {
-	return formMap{
-		workMinutes: &formItem{
-			title: "minutes: Pomodoro",
-			value: int(settings.Durations[session.Work].Minutes()),
-			kind:  numberItem,
-			limits: &limits{
-				min: minLimit,
-				max: maxLimit,
-			},
-		},
-		breakMinutes: &formItem{
-			title: "minutes: Break",
-			value: int(settings.Durations[session.Break].Minutes()),
-			kind:  numberItem,
-			limits: &limits{
-				min: minLimit,
-				max: maxLimit,
-			},
-		},
-		longBreakMinutes: &formItem{
-			title: "minutes: Long Break",
-			value: int(settings.Durations[session.LongBreak].Minutes()),
-			kind:  numberItem,
-			limits: &limits{
-				min: minLimit,
-				max: maxLimit,
-			},
-		},
-		workSessionsBeforeLongBreak: &formItem{
-			title: "Long Break interval",
-			value: settings.WorkSessionsUntilLongBreak,
-			kind:  numberItem,
-			limits: &limits{
-				min: 0,
-				max: maxLimit,
-			},
-		},
-		workAutoStart: &formItem{
-			title: "Auto start: Pomodoro",
-			value: toInt(settings.AutoStart[session.Work]),
-			kind:  toggleItem,
-		},
-		breakAutoStart: &formItem{
-			title: "Auto start: Break",
-			value: toInt(settings.AutoStart[session.Break]),
-			kind:  toggleItem,
-		},
-		longBreakAutoStart: &formItem{
-			title: "Auto start: Long Break",
-			value: toInt(settings.AutoStart[session.LongBreak]),
-			kind:  toggleItem,
-		},
-		soundNotification: &formItem{
-			title: "Sound notification",
-			value: toInt(settings.Notification.Sound),
-			kind:  toggleItem,
-		},
-		pushNotification: &formItem{
-			title: "Push notification",
-			value: toInt(settings.Notification.Push),
-			kind:  toggleItem,
-		},
-		showProgressBar: &formItem{
-			title: "Show progress bar",
-			value: toInt(settings.ShowProgressBar),
-			kind:  toggleItem,
-		},
-	}
-}
-
 type Model struct {
-	formMap  formMap
-	settings *Settings
+	form     form
+	settings *config.Settings
 	cursor   int
 	help     help.Model
 	keymap   keybinding.KeyMap
 	router   *router.Router
+	profile  string
+	err      error
+	// saved is what the settings file holds, changes of the form are
+	// shown against it and discarded on leaving without saving.
+	saved config.Settings
+	// section is the index of the shown section among shownSections.
+	section int
+	// filter matches items of all sections while it is not empty.
+	filter     textinput.Model
+	filterKeys keybinding.InputKeyMap
+	filtering  bool
+	// offset is the first visible position of the scrolled list.
+	offset int
+	height int
 }
 
 func (m *Model) resetSettings() {
-	settings := DefaultSettings()
+	settings := config.DefaultSettings()
 
 	m.settings = &settings
-	m.formMap = initFormMap(&settings)
+	m.form = newForm(&settings)
 }
 
-func (m *Model) listItems() []*formItem {
-	return []*formItem{
-		m.formMap.workMinutes,
-		m.formMap.breakMinutes,
-		m.formMap.longBreakMinutes,
-		m.formMap.workSessionsBeforeLongBreak,
-		m.formMap.workAutoStart,
-		m.formMap.breakAutoStart,
-		m.formMap.longBreakAutoStart,
-		m.formMap.soundNotification,
-		m.formMap.pushNotification,
-		m.formMap.showProgressBar,
+// resetItem sets the current item to its default value.
+func (m *Model) resetItem() {
+	index := m.fieldIndex()
+	if index < 0 {
+		return
 	}
+
+	defaults := config.DefaultSettings()
+	item := newForm(&defaults)[index]
+
+	m.form[index].value = item.value
+	m.form[index].text = item.text
+	m.form[index].err = nil
+	m.form.changed(index, m.settings)
 }
 
+// changedItems reports for each item whether it differs from the file.
+func (m *Model) changedItems() []bool {
+	saved := newForm(&m.saved)
+	changed := make([]bool, len(m.form))
+
+	for index, item := range m.form {
+		changed[index] = item.value != saved[index].value || item.text != saved[index].text
+	}
+
+	return changed
+}
+
+// currentItem is the item under the cursor, nil when nothing matches the
+// filter.
 func (m *Model) currentItem() *formItem {
-	return m.listItems()[m.cursor]
+	index := m.fieldIndex()
+	if index < 0 {
+		return nil
+	}
+
+	return m.form[index]
+}
+
+// changedCurrent runs the change of the item under the cursor.
+func (m *Model) changedCurrent() {
+	if index := m.fieldIndex(); index >= 0 {
+		m.form.changed(index, m.settings)
+	}
 }
 
+// Update scrolls the list after each message, the cursor, the filter, the
+// window size and errors of items change what fits on the screen.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cmd := m.update(msg)
+	m.scroll()
+
+	return m, cmd
+}
+
+func (m *Model) update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case SavedMsg:
+		// saved by a command, edits on the page are kept
+		if !m.changed() {
+			return m.Enter()
+		}
+	}
+
+	if item := m.currentItem(); item != nil && item.editing {
+		cmd := item.update(msg)
+		if !item.editing {
+			m.changedCurrent()
+		}
+
+		return cmd
+	}
+
+	if m.filtering {
+		return m.updateFilter(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
 	case tea.KeyMsg:
+		item := m.currentItem()
+
 		switch {
-		case key.Matches(msg, m.keymap.Reset):
-			m.resetSettings()
-		case key.Matches(msg, m.keymap.Help):
+		case keyboard.Matches(msg, m.keymap.Reset):
+			return m.router.Open(router.Confirm(i18n.T("Reset"), i18n.T("Reset all settings to defaults?"), func() tea.Cmd {
+				m.resetSettings()
+				return nil
+			}))
+		case keyboard.Matches(msg, m.keymap.Help):
 			m.help.ShowAll = !m.help.ShowAll
-		case key.Matches(msg, m.keymap.Back):
-			m.save()
-			return m.router.To(app.MainPageName)
-		case key.Matches(msg, m.keymap.Quit):
-			return m, tea.Quit
-		case key.Matches(msg, m.keymap.Enter):
-			m.currentItem().Enter()
-		case key.Matches(msg, m.keymap.Left):
-			m.currentItem().Decrease()
-		case key.Matches(msg, m.keymap.Right):
-			m.currentItem().Increase()
-		case key.Matches(msg, m.keymap.Up):
-			if m.cursor > 0 {
-				m.cursor--
+		case keyboard.Matches(msg, m.keymap.Command):
+			return m.router.OpenCommandLine()
+		case keyboard.Matches(msg, m.keymap.Filter):
+			return m.startFilter()
+		case keyboard.Matches(msg, m.keymap.NextSection):
+			m.moveSection(1)
+		case keyboard.Matches(msg, m.keymap.PrevSection):
+			m.moveSection(-1)
+		case keyboard.Matches(msg, m.keymap.ResetItem):
+			m.resetItem()
+		case keyboard.Matches(msg, m.keymap.Save):
+			m.err = m.save()
+			if m.err == nil {
+				return announceSaved
 			}
-		case key.Matches(msg, m.keymap.Down):
-			if m.cursor < len(m.listItems())-1 {
-				m.cursor++
+		case keyboard.Matches(msg, m.keymap.Back) && m.filter.Value() != "":
+			m.clearFilter()
+		case keyboard.Matches(msg, m.keymap.Back):
+			if m.changed() {
+				return m.router.Open(router.Confirm(i18n.T("Discard"), i18n.T("Discard unsaved changes?"), m.router.Pop))
 			}
+			return m.router.Pop()
+		case keyboard.Matches(msg, m.keymap.Quit):
+			if m.changed() {
+				return m.router.Open(router.Confirm(i18n.T("Quit"), i18n.T("Discard unsaved settings and quit?"), func() tea.Cmd {
+					return tea.Quit
+				}))
+			}
+			return tea.Quit
+		case keyboard.Matches(msg, m.keymap.Enter) && item != nil:
+			cmd := item.Enter()
+			m.changedCurrent()
+			return cmd
+		case keyboard.Matches(msg, m.keymap.Left) && item != nil:
+			item.Decrease()
+			m.changedCurrent()
+		case keyboard.Matches(msg, m.keymap.Right) && item != nil:
+			item.Increase()
+			m.changedCurrent()
+		case keyboard.Matches(msg, m.keymap.Up):
+			m.moveCursor(-1)
+		case keyboard.Matches(msg, m.keymap.Down):
+			m.moveCursor(1)
 		}
 	}
 
-	return m, nil
+	return nil
+}
+
+// SavedMsg is passed to all routes after the settings are saved on the
+// page.
+type SavedMsg struct{}
+
+func announceSaved() tea.Msg {
+	return SavedMsg{}
 }
 
-func mapToSettings(form formMap) Settings {
-	return Settings{
-		WorkSessionsUntilLongBreak: form.workSessionsBeforeLongBreak.value,
-		Durations: durations{
-			session.Work:      time.Minute * time.Duration(form.workMinutes.value),
-			session.Break:     time.Minute * time.Duration(form.breakMinutes.value),
-			session.LongBreak: time.Minute * time.Duration(form.longBreakMinutes.value),
-		},
-		ShowProgressBar: toBool(form.showProgressBar.value),
-		Notification: Notification{
-			Sound: toBool(form.soundNotification.value),
-			Push:  toBool(form.pushNotification.value),
-		},
-		AutoStart: AutoStart{
-			session.Work:      toBool(form.workAutoStart.value),
-			session.Break:     toBool(form.breakAutoStart.value),
-			session.LongBreak: toBool(form.longBreakAutoStart.value),
-		},
+func (m *Model) save() error {
+	settings := m.form.apply(m.settings)
+
+	err := newStorage().Save(settings)
+	if err != nil {
+		return err
 	}
-}
 
-func (m *Model) save() {
-	settings := mapToSettings(m.formMap)
+	m.saved = settings
+
+	// the page is shown in the saved language at once
+	i18n.Set(settings.Language)
+	m.keymap = keybinding.InitKeys()
+
+	return nil
+}
 
-	_ = newStorage().Save(settings)
+// changed reports whether the form differs from the settings file.
+func (m *Model) changed() bool {
+	return !reflect.DeepEqual(toFileSettings(m.form.apply(m.settings)), toFileSettings(m.saved))
 }
 
 func (m *Model) Init() tea.Cmd {
 	return nil
 }
 
+// Enter loads the form from the file each time the page is shown, so it
+// does not hold values edited elsewhere or discarded on leaving.
+func (m *Model) Enter() tea.Cmd {
+	if m.profile != profile.Current() {
+		m.profile = profile.Current()
+		m.section = 0
+		m.cursor = 0
+		m.offset = 0
+	}
+
+	m.settings, m.err = loadFile()
+	m.saved = m.settings.Clone()
+	m.form = newForm(m.settings)
+	m.scroll()
+
+	return nil
+}
+
+// Leave drops typing in progress, the form is loaded again on Enter.
+func (m *Model) Leave() tea.Cmd {
+	if item := m.currentItem(); item != nil {
+		item.editing = false
+	}
+
+	m.clearFilter()
+
+	return nil
+}
+
 func (m *Model) View() string {
-	s := settingsStyle.Render("Settings")
+	title := i18n.T("Settings: %s", m.profile)
+	if m.changed() {
+		title += i18n.T(" (unsaved)")
+	}
 
+	s := settingsStyle.Render(title)
+
+	s += "\n"
+	s += m.renderSections()
 	s += "\n"
 
-	for index, listItem := range m.listItems() {
-		cursor := " "
+	if m.filterShown() {
+		s += m.filter.View()
+		s += "\n"
+	}
 
-		if index == m.cursor {
-			cursor = ">"
-		}
+	s += m.renderList()
+
+	if description := m.renderDescription(); description != "" {
+		s += description
+		s += "\n"
+	}
+
+	if overridden := Overridden(); len(overridden) > 0 {
+		s += overriddenStyle.Render(i18n.T("Overridden by environment or flags: %s", strings.Join(overridden, ", ")))
+		s += "\n"
+	}
+
+	if m.err != nil {
+		s += errorStyle.Render(m.err.Error())
+		s += "\n"
+	}
+
+	if item := m.currentItem(); item != nil && item.editing {
+		return s + m.help.View(item.keymap)
+	}
 
-		s += fmt.Sprintf("%s %s\n", cursor, listItem.View())
+	if m.filtering {
+		return s + m.help.View(m.filterKeys)
 	}
 
 	s += m.help.View(m.keymap)
@@ -248,13 +332,17 @@ func (m *Model) View() string {
 }
 
 func NewModel(r *router.Router) *Model {
-	settings := NewSettings()
+	settings, err := loadFile()
 
 	return &Model{
-		formMap:  initFormMap(settings),
+		err:      err,
+		form:     newForm(settings),
 		settings: settings,
 		keymap:   keybinding.InitKeys(),
 		help:     help.New(),
 		router:   r,
+		profile:  profile.Current(),
+		saved:    settings.Clone(),
+		filter:   newFilter(),
 	}
 }
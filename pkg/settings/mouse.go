@@ -0,0 +1,46 @@
+package settings
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sectionsLine is the line of the section tabs, under the title.
+const sectionsLine = 1
+
+// updateMouse moves the cursor to a clicked item, toggles and the item
+// under the cursor take the click as enter. The wheel moves the cursor and
+// a click on a section tab shows the section.
+func (m *Model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	if msg.Action != tea.MouseActionPress {
+		return nil
+	}
+
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		m.moveCursor(-1)
+	case msg.Button == tea.MouseButtonWheelDown:
+		m.moveCursor(1)
+	case msg.Button != tea.MouseButtonLeft:
+		return nil
+	case msg.Y == sectionsLine && m.filter.Value() == "":
+		if index, ok := m.sectionAt(msg.X); ok {
+			m.moveSection(index - m.section)
+		}
+	default:
+		position, ok := m.positionAt(msg.Y)
+		if !ok {
+			return nil
+		}
+
+		clicked := position == m.cursor
+		m.cursor = position
+
+		if item := m.currentItem(); item != nil && (clicked || item.isToggle()) {
+			cmd := item.Enter()
+			m.changedCurrent()
+			return cmd
+		}
+	}
+
+	return nil
+}
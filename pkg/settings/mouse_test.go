@@ -0,0 +1,30 @@
+package settings
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/router"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+	"testing"
+)
+
+func TestMouseSections(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	r := router.NewRouter()
+	m := NewModel(&r)
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	sections := shownSections()
+	line := strings.Split(m.View(), "\n")[sectionsLine]
+	if !strings.Contains(line, sections[1].Title()) {
+		t.Fatalf("line %d = %q, want the sections", sectionsLine, line)
+	}
+
+	x := lipgloss.Width(sectionStyle.Render(sections[0].Title())) + 1
+	m.Update(tea.MouseMsg{X: x, Y: sectionsLine, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+
+	if m.section != 1 {
+		t.Errorf("section = %d after a click on %q, want 1", m.section, sections[1].Title())
+	}
+}
@@ -0,0 +1,325 @@
+package settings
+
+import (
+	"flag"
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard/layout"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const envPrefix = "POMOGORO_"
+
+// override is a setting that can be set from the environment and flags,
+// e.g. "long-break" is POMOGORO_LONG_BREAK and --long-break.
+type override struct {
+	name  string
+	usage string
+	apply func(s *config.Settings, value string) error
+	// values are suggested on the command line.
+	values []string
+	// boolean flags can be given without a value, e.g. --sound.
+	boolean bool
+}
+
+var overrides = []override{
+	durationOverride(session.Work),
+	durationOverride(session.Break),
+	durationOverride(session.LongBreak),
+	{
+		name:  "long-break-interval",
+		usage: "work sessions before the long break, 0 disables it",
+		apply: func(s *config.Settings, value string) error {
+			interval, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a number", value)
+			}
+
+			s.WorkSessionsUntilLongBreak = interval
+
+			return nil
+		},
+	},
+	{
+		name:  "mode",
+		usage: "classic or flowtime",
+		apply: func(s *config.Settings, value string) error {
+			s.Mode = config.Mode(value)
+
+			return nil
+		},
+		values: []string{string(config.ClassicMode), string(config.FlowtimeMode)},
+	},
+	{
+		name:  "language",
+		usage: "language of the UI: en, ru or auto for the locale",
+		apply: func(s *config.Settings, value string) error {
+			s.Language = i18n.Language(value)
+			if value == "auto" {
+				s.Language = i18n.Auto
+			}
+
+			return nil
+		},
+		values: []string{"auto", string(i18n.English), string(i18n.Russian)},
+	},
+	{
+		name:  "keyboard-layout",
+		usage: "keyboard layout mapped to the QWERTY keys, e.g. azerty or dvorak",
+		apply: func(s *config.Settings, value string) error {
+			s.KeyboardLayout = layout.Layout(value)
+			if value == "auto" {
+				s.KeyboardLayout = layout.Auto
+			}
+
+			return nil
+		},
+		values: layoutValues(),
+	},
+	{
+		name:  "theme",
+		usage: "colors of the sessions: tomato, ocean, forest or mono",
+		apply: func(s *config.Settings, value string) error {
+			s.Theme = config.Theme(value)
+
+			return nil
+		},
+		values: themeValues(),
+	},
+	{
+		name:  "tone",
+		usage: "built-in sound: ring, bell or beep",
+		apply: func(s *config.Settings, value string) error {
+			s.Notification.Tone = config.Tone(value)
+
+			return nil
+		},
+		values: toneValues(),
+	},
+	boolOverride("count-skipped", "count skipped work sessions toward the long break", func(s *config.Settings, value bool) {
+		s.CountSkipped = value
+	}),
+	boolOverride("show-progress-bar", "show the progress bar", func(s *config.Settings, value bool) {
+		s.ShowProgressBar = value
+	}),
+	boolOverride("mouse", "click and scroll with the mouse", func(s *config.Settings, value bool) {
+		s.Mouse = value
+	}),
+	boolOverride("terminal-title", "show the time in the title of the terminal", func(s *config.Settings, value bool) {
+		s.TerminalTitle = value
+	}),
+	boolOverride("terminal-progress", "show the progress in the taskbar or tab of the terminal", func(s *config.Settings, value bool) {
+		s.TerminalProgress = value
+	}),
+	boolOverride("sound", "sound notification", func(s *config.Settings, value bool) {
+		s.Notification.Sound = value
+	}),
+	boolOverride("push", "push notification", func(s *config.Settings, value bool) {
+		s.Notification.Push = value
+	}),
+	autoStartOverride(session.Work),
+	autoStartOverride(session.Break),
+	autoStartOverride(session.LongBreak),
+	overtimeOverride(session.Work),
+	overtimeOverride(session.Break),
+	overtimeOverride(session.LongBreak),
+}
+
+func layoutValues() []string {
+	values := []string{"auto"}
+	for _, l := range layout.Layouts {
+		values = append(values, string(l))
+	}
+
+	return values
+}
+
+func themeValues() []string {
+	values := make([]string, len(config.Themes))
+	for index, theme := range config.Themes {
+		values[index] = string(theme)
+	}
+
+	return values
+}
+
+func toneValues() []string {
+	values := make([]string, len(config.Tones))
+	for index, tone := range config.Tones {
+		values[index] = string(tone)
+	}
+
+	return values
+}
+
+// flagValues are the overrides given on the command line.
+var flagValues = make(map[string]string)
+
+func optionName(sessionType session.Type) string {
+	return strings.ReplaceAll(sessionType.Name(), "_", "-")
+}
+
+func durationOverride(sessionType session.Type) override {
+	return override{
+		name:  optionName(sessionType),
+		usage: fmt.Sprintf("duration of %s, e.g. 25m", optionName(sessionType)),
+		apply: func(s *config.Settings, value string) error {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a duration, use values like \"25m\" or \"1h30m\"", value)
+			}
+
+			s.Durations[sessionType] = duration
+
+			return nil
+		},
+	}
+}
+
+func autoStartOverride(sessionType session.Type) override {
+	name := "auto-start-" + optionName(sessionType)
+
+	return boolOverride(name, fmt.Sprintf("auto start %s", optionName(sessionType)), func(s *config.Settings, value bool) {
+		s.AutoStart[sessionType] = value
+	})
+}
+
+func overtimeOverride(sessionType session.Type) override {
+	name := "overtime-" + optionName(sessionType)
+
+	return boolOverride(name, fmt.Sprintf("overtime for %s", optionName(sessionType)), func(s *config.Settings, value bool) {
+		s.Overtime[sessionType] = value
+	})
+}
+
+func boolOverride(name string, usage string, set func(s *config.Settings, value bool)) override {
+	return override{
+		name:  name,
+		usage: usage,
+		apply: func(s *config.Settings, value string) error {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a boolean", value)
+			}
+
+			set(s, parsed)
+
+			return nil
+		},
+		values:  []string{"true", "false"},
+		boolean: true,
+	}
+}
+
+func (o override) envName() string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(o.name, "-", "_"))
+}
+
+type flagValue struct {
+	name    string
+	boolean bool
+}
+
+func (v flagValue) String() string {
+	return flagValues[v.name]
+}
+
+func (v flagValue) Set(value string) error {
+	flagValues[v.name] = value
+
+	return nil
+}
+
+// IsBoolFlag lets the flag package take a boolean flag without a value as
+// true.
+func (v flagValue) IsBoolFlag() bool {
+	return v.boolean
+}
+
+// RegisterFlags adds a flag for every override, flags take precedence over
+// POMOGORO_* environment variables which take precedence over the file.
+func RegisterFlags(flags *flag.FlagSet) {
+	for _, o := range overrides {
+		flags.Var(flagValue{name: o.name, boolean: o.boolean}, o.name, fmt.Sprintf("%s (env %s)", o.usage, o.envName()))
+	}
+}
+
+// applyOverrides applies environment variables and then flags, the names
+// of the overridden settings are returned.
+func applyOverrides(s *config.Settings) ([]string, error) {
+	var applied []string
+
+	for _, o := range overrides {
+		overridden := false
+
+		if value, ok := os.LookupEnv(o.envName()); ok {
+			if err := o.apply(s, value); err != nil {
+				return applied, fmt.Errorf("%s: %w", o.envName(), err)
+			}
+			overridden = true
+		}
+
+		if value, ok := flagValues[o.name]; ok {
+			if err := o.apply(s, value); err != nil {
+				return applied, fmt.Errorf("--%s: %w", o.name, err)
+			}
+			overridden = true
+		}
+
+		if overridden {
+			applied = append(applied, o.name)
+		}
+	}
+
+	return applied, nil
+}
+
+// Names returns the names of the settings that can be overridden or set
+// with Set.
+func Names() []string {
+	names := make([]string, len(overrides))
+	for index, o := range overrides {
+		names[index] = o.name
+	}
+
+	return names
+}
+
+// Values suggests values of the setting name, nil when any value goes.
+func Values(name string) []string {
+	for _, o := range overrides {
+		if o.name == name {
+			return o.values
+		}
+	}
+
+	return nil
+}
+
+// Set changes a setting in the file of the current profile, name is one of
+// Names, e.g. Set("work", "50m").
+func Set(name, value string) error {
+	for _, o := range overrides {
+		if o.name != name {
+			continue
+		}
+
+		s, err := loadFile()
+		if err != nil {
+			return err
+		}
+
+		if err := o.apply(s, value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		return newStorage().Save(*s)
+	}
+
+	return fmt.Errorf("unknown setting %q", name)
+}
@@ -0,0 +1,345 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
+	"github.com/borissimkin/pomogoro/pkg/settings/keybinding"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"math"
+	"strings"
+)
+
+// chromeHeight is the number of lines around the list: title, sections,
+// filter, scroll indicators, description, message and help.
+const (
+	chromeHeight = 9
+	minRows      = 3
+)
+
+var (
+	sectionStyle = lipgloss.NewStyle().
+			Padding(0, 1).
+			Faint(true)
+	activeSectionStyle = lipgloss.NewStyle().
+				Padding(0, 1).
+				Bold(true).
+				Underline(true)
+	descriptionStyle = lipgloss.NewStyle().
+				Faint(true).
+				Italic(true)
+	moreStyle = lipgloss.NewStyle().
+			Faint(true)
+)
+
+// shownSections are the sections that have fields.
+func shownSections() []section {
+	var shown []section
+
+	for _, s := range sections {
+		for _, field := range fields {
+			if field.section == s {
+				shown = append(shown, s)
+				break
+			}
+		}
+	}
+
+	return shown
+}
+
+// visible returns the indexes of the fields in the list: the fields of the
+// current section or, while there is a filter, the matching fields of all
+// sections.
+func (m *Model) visible() []int {
+	var indexes []int
+
+	query := strings.ToLower(strings.TrimSpace(m.filter.Value()))
+	current := shownSections()[m.section]
+
+	for index, field := range fields {
+		if query == "" {
+			if field.section == current {
+				indexes = append(indexes, index)
+			}
+			continue
+		}
+
+		text := strings.ToLower(strings.Join([]string{field.item.Title(), field.section.Title(), i18n.T(field.description)}, " "))
+		if strings.Contains(text, query) {
+			indexes = append(indexes, index)
+		}
+	}
+
+	return indexes
+}
+
+// fieldIndex is the index of the field under the cursor, -1 when nothing
+// matches the filter.
+func (m *Model) fieldIndex() int {
+	visible := m.visible()
+	if m.cursor >= len(visible) {
+		return -1
+	}
+
+	return visible[m.cursor]
+}
+
+func (m *Model) moveSection(delta int) {
+	count := len(shownSections())
+
+	m.section = (m.section + delta + count) % count
+	m.filter.SetValue("")
+	m.cursor = 0
+	m.offset = 0
+}
+
+func (m *Model) moveCursor(delta int) {
+	m.cursor = max(min(m.cursor+delta, len(m.visible())-1), 0)
+	m.scroll()
+}
+
+// rows is the number of lines the items can take on the screen, all of
+// them before the window size is known.
+func (m *Model) rows() int {
+	if m.height <= 0 {
+		return math.MaxInt
+	}
+
+	return max(m.height-chromeHeight, minRows)
+}
+
+// itemHeight is the number of lines of the field, an item with an error
+// shows it on a line of its own.
+func (m *Model) itemHeight(index int) int {
+	return lipgloss.Height(m.form[index].View())
+}
+
+// end is the position after the last of the visible fields that fits on
+// the screen from the offset, the field at the offset is always shown.
+func (m *Model) end(visible []int) int {
+	end := m.offset
+	for lines := 0; end < len(visible); end++ {
+		lines += m.itemHeight(visible[end])
+		if lines > m.rows() && end > m.offset {
+			break
+		}
+	}
+
+	return end
+}
+
+// fitsFrom reports whether the visible fields from the position to the end
+// of the list fit on the screen.
+func (m *Model) fitsFrom(visible []int, position int) bool {
+	lines := 0
+	for _, index := range visible[position:] {
+		lines += m.itemHeight(index)
+	}
+
+	return lines <= m.rows()
+}
+
+// scroll keeps the cursor inside the viewport and the viewport full.
+func (m *Model) scroll() {
+	visible := m.visible()
+	if len(visible) == 0 {
+		m.cursor = 0
+		m.offset = 0
+		return
+	}
+
+	m.cursor = min(m.cursor, len(visible)-1)
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+
+	for m.cursor >= m.end(visible) {
+		m.offset++
+	}
+
+	for m.offset > 0 && m.fitsFrom(visible, m.offset-1) {
+		m.offset--
+	}
+}
+
+func (m *Model) startFilter() tea.Cmd {
+	m.filtering = true
+	m.filterKeys = keybinding.InitInputKeys()
+	m.filterKeys.Complete.SetEnabled(false)
+
+	return m.filter.Focus()
+}
+
+// updateFilter handles a message while the filter is typed, the list is
+// filtered as it changes.
+func (m *Model) updateFilter(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case keyboard.Matches(msg, m.filterKeys.Confirm):
+			m.filtering = false
+			m.filter.Blur()
+			return nil
+		case keyboard.Matches(msg, m.filterKeys.Cancel):
+			m.clearFilter()
+			return nil
+		case keyboard.Matches(msg, m.keymap.Up):
+			if msg.Type == tea.KeyUp {
+				m.moveCursor(-1)
+				return nil
+			}
+		case keyboard.Matches(msg, m.keymap.Down):
+			if msg.Type == tea.KeyDown {
+				m.moveCursor(1)
+				return nil
+			}
+		}
+	}
+
+	value := m.filter.Value()
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+
+	if m.filter.Value() != value {
+		m.cursor = 0
+		m.offset = 0
+	}
+
+	return cmd
+}
+
+func (m *Model) clearFilter() {
+	m.filtering = false
+	m.filter.Blur()
+	m.filter.SetValue("")
+	m.cursor = 0
+	m.offset = 0
+}
+
+func newFilter() textinput.Model {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = i18n.T("filter")
+
+	return filter
+}
+
+func (m *Model) renderSections() string {
+	if m.filter.Value() != "" {
+		return sectionStyle.Render(i18n.T("All sections"))
+	}
+
+	var tabs []string
+
+	for index, s := range shownSections() {
+		style := sectionStyle
+		if index == m.section {
+			style = activeSectionStyle
+		}
+
+		tabs = append(tabs, style.Render(s.Title()))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+// renderList renders the items in the viewport with markers of changed
+// items and of items above and below it.
+func (m *Model) renderList() string {
+	visible := m.visible()
+	if len(visible) == 0 {
+		return moreStyle.Render("  "+i18n.T("No settings match the filter")) + "\n"
+	}
+
+	changed := m.changedItems()
+	end := m.end(visible)
+
+	s := ""
+
+	if m.offset > 0 {
+		s += moreStyle.Render("  "+i18n.T("↑ %d more", m.offset)) + "\n"
+	}
+
+	for position := m.offset; position < end; position++ {
+		index := visible[position]
+
+		cursor := " "
+		if position == m.cursor {
+			cursor = ">"
+		}
+
+		marker := " "
+		if changed[index] {
+			marker = changedStyle.Render("*")
+		}
+
+		s += fmt.Sprintf("%s%s %s\n", cursor, marker, m.form[index].View())
+	}
+
+	if end < len(visible) {
+		s += moreStyle.Render("  "+i18n.T("↓ %d more", len(visible)-end)) + "\n"
+	}
+
+	return s
+}
+
+func (m *Model) renderDescription() string {
+	index := m.fieldIndex()
+	if index < 0 {
+		return ""
+	}
+
+	return descriptionStyle.Render(i18n.T(fields[index].description))
+}
+
+// filterShown reports whether the filter line is shown over the list.
+func (m *Model) filterShown() bool {
+	return m.filtering || m.filter.Value() != ""
+}
+
+// positionAt returns the position in the list of the item at the line y
+// of the view, the lines follow View and renderList.
+func (m *Model) positionAt(y int) (int, bool) {
+	line := sectionsLine + 1
+	if m.filterShown() {
+		line++
+	}
+
+	if m.offset > 0 {
+		line++
+	}
+
+	visible := m.visible()
+	end := m.end(visible)
+
+	for position := m.offset; position < end; position++ {
+		height := m.itemHeight(visible[position])
+		if y >= line && y < line+height {
+			return position, true
+		}
+
+		line += height
+	}
+
+	return 0, false
+}
+
+// sectionAt returns the index of the section tab at x of the tabs line.
+func (m *Model) sectionAt(x int) (int, bool) {
+	left := 0
+
+	for index, s := range shownSections() {
+		right := left + lipgloss.Width(sectionStyle.Render(s.Title()))
+		if x >= left && x < right {
+			return index, true
+		}
+
+		left = right
+	}
+
+	return 0, false
+}
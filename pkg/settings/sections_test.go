@@ -0,0 +1,92 @@
+package settings
+
+import (
+	"errors"
+	"github.com/borissimkin/pomogoro/pkg/router"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+	"testing"
+)
+
+// newTestList opens the page with room for lines of items and shows an
+// error under the first item.
+func newTestList(t *testing.T, lines int) *Model {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	r := router.NewRouter()
+	m := NewModel(&r)
+	m.Enter()
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: chromeHeight + lines})
+
+	if len(m.visible()) < 4 {
+		t.Fatalf("the first section has %d items, want at least 4", len(m.visible()))
+	}
+
+	m.form[m.visible()[0]].err = errors.New("not valid")
+
+	return m
+}
+
+func TestListScroll(t *testing.T) {
+	tests := []struct {
+		name       string
+		cursor     int
+		wantOffset int
+	}{
+		// the error line of the first item takes the line of the third
+		{name: "second item", cursor: 1, wantOffset: 0},
+		{name: "third item", cursor: 2, wantOffset: 1},
+		{name: "fourth item", cursor: 3, wantOffset: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestList(t, 3)
+
+			for range test.cursor {
+				m.Update(tea.KeyMsg{Type: tea.KeyDown})
+			}
+
+			if m.offset != test.wantOffset {
+				t.Errorf("offset = %d, want %d", m.offset, test.wantOffset)
+			}
+
+			list := m.renderList()
+			if !strings.Contains(list, ">") {
+				t.Errorf("the cursor is not in the list:\n%s", list)
+			}
+		})
+	}
+}
+
+func TestListClick(t *testing.T) {
+	m := newTestList(t, 10)
+
+	// the list starts under the sections, the first item takes two lines
+	top := sectionsLine + 1
+
+	tests := []struct {
+		y      int
+		want   int
+		wantOk bool
+	}{
+		{y: top, want: 0, wantOk: true},
+		{y: top + 1, want: 0, wantOk: true},
+		{y: top + 2, want: 1, wantOk: true},
+		{y: top + 3, want: 2, wantOk: true},
+		{y: sectionsLine, wantOk: false},
+	}
+
+	for _, test := range tests {
+		got, ok := m.positionAt(test.y)
+		if ok != test.wantOk || got != test.want {
+			t.Errorf("positionAt(%d) = %d, %v, want %d, %v", test.y, got, ok, test.want, test.wantOk)
+		}
+	}
+
+	lines := strings.Split(m.View(), "\n")
+	if !strings.Contains(lines[top+1], "not valid") {
+		t.Errorf("line %d = %q, want the error of the first item", top+1, lines[top+1])
+	}
+}
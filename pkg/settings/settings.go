@@ -1,59 +1,50 @@
+// Package settings loads and saves the config.Settings of a profile,
+// applies environment variables and flags on top and edits them on the
+// settings page.
 package settings
 
 import (
-	"github.com/borissimkin/pomogoro/pkg/session"
-	"time"
+	"errors"
+	"github.com/borissimkin/pomogoro/pkg/config"
 )
 
-type durations map[session.Type]time.Duration
+// NewSettings reads the settings of the current profile and applies the
+// POMOGORO_* environment variables and flags on top. Defaults are returned
+// along with the error when the saved settings can not be used.
+func NewSettings() (*config.Settings, error) {
+	s, err := loadFile()
 
-type Notification struct {
-	Sound bool
-	Push  bool
-}
+	overridden := s.Clone()
 
-type AutoStart map[session.Type]bool
+	_, overrideErr := applyOverrides(&overridden)
+	if overrideErr == nil {
+		overrideErr = overridden.Validate()
+	}
+	if overrideErr != nil {
+		return s, errors.Join(err, overrideErr)
+	}
 
-type Settings struct {
-	WorkSessionsUntilLongBreak int
-	Durations                  durations
-	ShowProgressBar            bool
-	Notification               Notification
-	AutoStart                  AutoStart
+	return &overridden, err
 }
 
-func DefaultSettings() Settings {
-	return Settings{
-		WorkSessionsUntilLongBreak: 4,
-		ShowProgressBar:            true,
-		Notification: Notification{
-			Sound: true,
-			Push:  true,
-		},
-		AutoStart: AutoStart{
-			session.Work:      true,
-			session.Break:     true,
-			session.LongBreak: true,
-		},
-		Durations: durations{
-			session.Work:      time.Minute * 25,
-			session.Break:     time.Minute * 5,
-			session.LongBreak: time.Minute * 15,
-		},
-	}
+// Overridden returns the names of the settings that are set by environment
+// variables or flags.
+func Overridden() []string {
+	s := config.DefaultSettings()
+
+	names, _ := applyOverrides(&s)
+
+	return names
 }
 
-func NewSettings() *Settings {
-	old := newStorage().Read()
+// loadFile reads the settings file of the current profile without overrides.
+func loadFile() (*config.Settings, error) {
+	old, err := newStorage().Read()
 	if old != nil {
-		return old
+		return old, nil
 	}
 
-	s := DefaultSettings()
-
-	return &s
-}
+	s := config.DefaultSettings()
 
-func (s *Settings) GetDuration(sessionType session.Type) time.Duration {
-	return s.Durations[sessionType]
+	return &s, err
 }
@@ -1,35 +1,44 @@
 package settings
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/profile"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 type storage interface {
-	Save(settings Settings) error
-	Read() *Settings
+	Save(settings config.Settings) error
+	Read() (*config.Settings, error)
 }
 
 const (
-	folder   = "pomogoro"
-	filename = "settings.json"
+	filename       = "config.toml"
+	backupFilename = "config.toml.bak"
+	legacyFilename = "settings.json"
 )
 
-var saved *Settings = nil
+// tomlStorage keeps the settings in config.toml of the current profile.
+type tomlStorage struct{}
 
-type jsonStorage struct {
-	storage
+func newStorage() storage {
+	return &tomlStorage{}
 }
 
-func newStorage() storage {
-	return &jsonStorage{}
+// Files are the files of a profile that are copied when it is cloned.
+func Files() []string {
+	return []string{filename, legacyFilename}
 }
 
 func getPath() string {
-	path, _ := os.UserConfigDir()
-
-	return filepath.Join(path, folder)
+	return profile.Dir(profile.Current())
 }
 
 func getFullPath() string {
@@ -38,12 +47,32 @@ func getFullPath() string {
 	return fullPath
 }
 
-func (s *jsonStorage) Save(settings Settings) error {
-	saved = &settings
+func getBackupPath() string {
+	return filepath.Join(getPath(), backupFilename)
+}
+
+func getLegacyPath() string {
+	return filepath.Join(getPath(), legacyFilename)
+}
+
+// Save validates settings and replaces the file atomically, the previous
+// file is kept as a backup.
+func (s *tomlStorage) Save(settings config.Settings) error {
+	settings.Version = config.CurrentVersion
+
+	err := settings.Validate()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
 
-	bytes, err := json.Marshal(settings)
+	encoder := toml.NewEncoder(&buf)
+	encoder.Indent = ""
+
+	err = encoder.Encode(toFileSettings(settings))
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("encode settings: %w", err)
 	}
 
 	err = os.MkdirAll(getPath(), 0700)
@@ -51,24 +80,151 @@ func (s *jsonStorage) Save(settings Settings) error {
 		return err
 	}
 
-	err = os.WriteFile(getFullPath(), bytes, 0644)
+	err = backup()
 	if err != nil {
-		return err
+		return fmt.Errorf("backup settings: %w", err)
 	}
 
-	return nil
+	return writeAtomic(getFullPath(), buf.Bytes())
 }
 
-func (s *jsonStorage) Read() *Settings {
+// Read returns nil settings without an error when there is no file yet.
+// Files of older versions, including settings.json written before the
+// toml config, are migrated in memory and rewritten on the next Save.
+func (s *tomlStorage) Read() (*config.Settings, error) {
+	doc, path, err := readDoc()
+	if doc == nil || err != nil {
+		return nil, err
+	}
+
+	err = migrate(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+
+	err = toml.NewEncoder(&buf).Encode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	file := toFileSettings(config.DefaultSettings())
+	defaultBrackets := file.Flowtime.Brackets
+	// decoding reuses the elements of a slice, brackets must not inherit
+	// the fields of the defaults
+	file.Flowtime.Brackets = nil
+
+	meta, err := toml.Decode(buf.String(), &file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if !meta.IsDefined("flowtime", "brackets") {
+		file.Flowtime.Brackets = defaultBrackets
+	}
+
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, 0, len(undecoded))
+		for _, key := range undecoded {
+			keys = append(keys, key.String())
+		}
+
+		return nil, fmt.Errorf("%s: unknown settings: %s", path, strings.Join(keys, ", "))
+	}
+
+	settings := file.toSettings()
+
+	err = settings.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// readDoc decodes config.toml or, when there is none yet, the legacy
+// settings.json into a raw document for migrations.
+func readDoc() (map[string]any, string, error) {
+	doc := make(map[string]any)
+
 	file, err := os.ReadFile(getFullPath())
+	if err == nil {
+		_, err = toml.Decode(string(file), &doc)
+		if err != nil {
+			return nil, getFullPath(), fmt.Errorf("%s is not valid toml: %w", getFullPath(), err)
+		}
+
+		return doc, getFullPath(), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, getFullPath(), err
+	}
+
+	file, err = os.ReadFile(getLegacyPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, getLegacyPath(), nil
+	}
+	if err != nil {
+		return nil, getLegacyPath(), err
+	}
+
+	err = json.Unmarshal(file, &doc)
+	if err != nil {
+		return nil, getLegacyPath(), fmt.Errorf("%s is not valid json: %w", getLegacyPath(), err)
+	}
+
+	return doc, getLegacyPath(), nil
+}
+
+func backup() error {
+	bytes, err := os.ReadFile(getFullPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
 	if err != nil {
-		return saved
+		return err
 	}
 
-	err = json.Unmarshal(file, &saved)
+	return writeAtomic(getBackupPath(), bytes)
+}
+
+func writeAtomic(path string, bytes []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
 	if err != nil {
-		return saved
+		return err
+	}
+
+	_, err = tmp.Write(bytes)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// ModTime returns the modification time of the settings file of the current
+// profile, zero when there is no file.
+func ModTime() time.Time {
+	for _, path := range []string{getFullPath(), getLegacyPath()} {
+		info, err := os.Stat(path)
+		if err == nil {
+			return info.ModTime()
+		}
 	}
 
-	return saved
+	return time.Time{}
 }
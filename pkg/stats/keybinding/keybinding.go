@@ -0,0 +1,49 @@
+package keybinding
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type KeyMap struct {
+	Help    key.Binding
+	Command key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Help,
+		k.Command,
+		k.Back,
+		k.Quit,
+	}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Back, k.Help, k.Quit, k.Command},
+	}
+}
+
+func InitKeys() KeyMap {
+	return KeyMap{
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", i18n.T("command")),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "b"),
+			key.WithHelp("esc/b", i18n.T("back")),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", i18n.T("quit")),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("/", "?"),
+			key.WithHelp("?", i18n.T("help")),
+		),
+	}
+}
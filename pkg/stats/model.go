@@ -0,0 +1,161 @@
+// Package stats is the page with the statistics of the current profile for
+// today, the last 7 days and all time, it is opened with `:goto stats`.
+package stats
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"github.com/borissimkin/pomogoro/pkg/history"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/keyboard"
+	"github.com/borissimkin/pomogoro/pkg/profile"
+	"github.com/borissimkin/pomogoro/pkg/router"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"github.com/borissimkin/pomogoro/pkg/stats/keybinding"
+	"github.com/charmbracelet/bubbles/help"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+	"time"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("229")).
+			Background(lipgloss.Color("57")).
+			MarginLeft(2).
+			PaddingLeft(1).
+			PaddingRight(1)
+	periodStyle = lipgloss.NewStyle().Bold(true).PaddingLeft(2).PaddingRight(2)
+	errorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+)
+
+// period is a row of the page, it counts the records finished since.
+type period struct {
+	title string
+	since func(today time.Time) time.Time
+}
+
+var periods = []period{
+	{title: "Today", since: func(today time.Time) time.Time { return today }},
+	{title: "Last 7 days", since: func(today time.Time) time.Time { return today.AddDate(0, 0, -6) }},
+	{title: "All time", since: func(time.Time) time.Time { return time.Time{} }},
+}
+
+type Model struct {
+	profile string
+	// stats are in the order of periods.
+	stats  []history.Stats
+	err    error
+	help   help.Model
+	keymap keybinding.KeyMap
+	router *router.Router
+}
+
+// load reads the history of the current profile again.
+func (m *Model) load() {
+	m.profile = profile.Current()
+	m.stats = make([]history.Stats, len(periods))
+
+	records, err := history.Read(m.profile)
+	m.err = err
+
+	for _, record := range records {
+		m.add(record)
+	}
+}
+
+func (m *Model) add(record history.Record) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	for index, p := range periods {
+		if !record.FinishedAt.Before(p.since(today)) {
+			m.stats[index].Add(record)
+		}
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.help.Width = msg.Width
+	case event.Completed:
+		if msg.Profile == m.profile {
+			m.add(history.Record{SessionType: msg.SessionType, FinishedAt: msg.At, Duration: msg.Duration, Overtime: msg.Overtime})
+		}
+	case tea.KeyMsg:
+		switch {
+		case keyboard.Matches(msg, m.keymap.Help):
+			m.help.ShowAll = !m.help.ShowAll
+		case keyboard.Matches(msg, m.keymap.Command):
+			return m, m.router.OpenCommandLine()
+		case keyboard.Matches(msg, m.keymap.Back):
+			return m, m.router.Pop()
+		case keyboard.Matches(msg, m.keymap.Quit):
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Enter reads the statistics again, the profile and the language may have
+// changed.
+func (m *Model) Enter() tea.Cmd {
+	m.keymap = keybinding.InitKeys()
+	m.load()
+
+	return nil
+}
+
+func renderStats(stats history.Stats) string {
+	s := i18n.N(
+		"%d pomodoro, %v focused",
+		"%d pomodoros, %v focused",
+		stats.Completed[session.Work],
+		stats.Focused.Truncate(time.Minute),
+	)
+
+	if stats.Overtime >= time.Minute {
+		s += i18n.T(", %v overtime", stats.Overtime.Truncate(time.Minute))
+	}
+
+	breaks := stats.Completed[session.Break] + stats.Completed[session.LongBreak]
+
+	return s + i18n.N(", %d break", ", %d breaks", breaks)
+}
+
+func (m *Model) View() string {
+	s := titleStyle.Render(i18n.T("Statistics: %s", m.profile)) + "\n\n"
+
+	width := 0
+	for _, p := range periods {
+		width = max(width, lipgloss.Width(i18n.T(p.title)))
+	}
+
+	var rows []string
+	for index, p := range periods {
+		rows = append(rows, periodStyle.Width(width+4).Render(i18n.T(p.title))+renderStats(m.stats[index]))
+	}
+
+	s += strings.Join(rows, "\n") + "\n\n"
+
+	if m.err != nil {
+		s += errorStyle.Render(m.err.Error()) + "\n"
+	}
+
+	return s + m.help.View(m.keymap)
+}
+
+func NewModel(r *router.Router) *Model {
+	return &Model{
+		stats:  make([]history.Stats, len(periods)),
+		help:   help.New(),
+		keymap: keybinding.InitKeys(),
+		router: r,
+	}
+}
@@ -0,0 +1,76 @@
+package status
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/template"
+	"time"
+)
+
+const watchInterval = time.Second
+
+// Run implements the `pomogoro status` subcommand.
+func Run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	format := flags.String("format", DefaultTemplate, "output template, fields: .Icon .Remaining .Session .Profile .Completed .Goal .Running .Percent .Class")
+	watch := flags.Bool("watch", false, "reprint the status every second")
+	waybar := flags.Bool("waybar", false, "print waybar compatible json")
+	profile := flags.String("profile", "", "show the instance running this profile, by default a running one")
+
+	err := flags.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("status").Parse(*format)
+	if err != nil {
+		return err
+	}
+
+	printStatus := func() error {
+		state, err := Read(*profile)
+		if err != nil {
+			return err
+		}
+
+		if state != nil {
+			live := state.Live(time.Now())
+			state = &live
+		}
+
+		data := newData(state)
+
+		var line string
+		if *waybar {
+			line, err = renderWaybar(tmpl, data)
+		} else {
+			line, err = render(tmpl, data)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(out, line)
+
+		return err
+	}
+
+	if !*watch {
+		return printStatus()
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		err = printStatus()
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"text/template"
+	"time"
+)
+
+const (
+	DefaultTemplate = "{{.Icon}} {{.Remaining}} {{.Session}} {{.Completed}}/{{.Goal}}"
+	pausedIcon      = "⏸"
+	idleClass       = "idle"
+	pausedClass     = "paused"
+	overtimeClass   = "overtime"
+)
+
+var sessions = map[session.Type]*session.Session{
+	session.Work:      &session.WorkSession,
+	session.Break:     &session.BreakSession,
+	session.LongBreak: &session.LongBreakSession,
+}
+
+// Data is what user templates are executed against.
+type Data struct {
+	Icon      string
+	Remaining string
+	Session   string
+	Profile   string
+	Completed int
+	Goal      int
+	Running   bool
+	Overtime  bool
+	Percent   int
+	Class     string
+}
+
+type waybarOutput struct {
+	Text       string   `json:"text"`
+	Tooltip    string   `json:"tooltip"`
+	Class      []string `json:"class"`
+	Percentage int      `json:"percentage"`
+}
+
+func formatRemaining(t time.Duration) string {
+	t = t.Truncate(time.Second)
+
+	minutes := int(t / time.Minute)
+	seconds := int(t % time.Minute / time.Second)
+
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
+
+func newData(state *State) Data {
+	if state == nil {
+		return Data{Class: idleClass}
+	}
+
+	s := sessions[state.SessionType]
+	if state.Flowtime && state.SessionType == session.Work {
+		s = &session.FlowtimeSession
+	}
+
+	data := Data{
+		Icon:      s.Icon,
+		Remaining: formatRemaining(state.Remaining),
+		Session:   i18n.T(s.Title),
+		Profile:   state.Profile,
+		Completed: state.Completed,
+		Goal:      state.Goal,
+		Running:   state.Running,
+		Overtime:  state.Overtime,
+		Class:     state.SessionType.Name(),
+	}
+
+	if state.Overtime {
+		data.Remaining = "+" + data.Remaining
+		data.Percent = 100
+	}
+
+	if !state.Running {
+		data.Icon = pausedIcon
+	}
+
+	if state.Initial > 0 && !state.CountingUp {
+		data.Percent = int(100 * (state.Initial - state.Remaining) / state.Initial)
+	}
+
+	return data
+}
+
+// Title is state as the title of the terminal window, e.g. "🍅 12:34 Pomodoro".
+func Title(state State) string {
+	data := newData(&state)
+
+	return fmt.Sprintf("%s %s %s", data.Icon, data.Remaining, data.Session)
+}
+
+func render(tmpl *template.Template, data Data) (string, error) {
+	var buf bytes.Buffer
+
+	if data.Class == idleClass {
+		return "", nil
+	}
+
+	err := tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func renderWaybar(tmpl *template.Template, data Data) (string, error) {
+	text, err := render(tmpl, data)
+	if err != nil {
+		return "", err
+	}
+
+	classes := []string{data.Class}
+	if data.Class != idleClass && !data.Running {
+		classes = append(classes, pausedClass)
+	}
+	if data.Overtime {
+		classes = append(classes, overtimeClass)
+	}
+
+	out := waybarOutput{
+		Text:       text,
+		Tooltip:    data.Session,
+		Class:      classes,
+		Percentage: data.Percent,
+	}
+
+	bytes, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
@@ -0,0 +1,211 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	folder = "pomogoro"
+	subdir = "instances"
+	ext    = ".json"
+)
+
+// State is a snapshot of a running instance that is shared with
+// `pomogoro status` through a file in the user cache directory, every
+// instance has a file of its own named by its PID.
+type State struct {
+	Profile     string
+	SessionType session.Type
+	Running     bool
+	Remaining   time.Duration
+	Initial     time.Duration
+	Completed   int
+	Goal        int
+	UpdatedAt   time.Time
+	Flowtime    bool
+	// CountingUp is set for flowtime work and overtime, Remaining is the
+	// elapsed time or the overtime.
+	CountingUp bool
+	Overtime   bool
+	// PID is the process of the running instance, the state is left behind
+	// when it is killed.
+	PID int
+}
+
+// Equal reports whether other is what s shows at the time of other: a
+// clock that kept running or stayed paused since s does not differ, Live
+// projects it.
+func (s State) Equal(other State) bool {
+	projected := s.Live(other.UpdatedAt)
+	diff := projected.Remaining - other.Remaining
+
+	projected.Remaining = other.Remaining
+	projected.UpdatedAt = other.UpdatedAt
+
+	return projected == other && diff < time.Second && diff > -time.Second
+}
+
+// Live returns the state projected to now: a running timer keeps counting
+// down between the writes of the running instance.
+func (s State) Live(now time.Time) State {
+	if !s.Running {
+		return s
+	}
+
+	if s.CountingUp {
+		s.Remaining += now.Sub(s.UpdatedAt)
+		s.UpdatedAt = now
+
+		return s
+	}
+
+	s.Remaining -= now.Sub(s.UpdatedAt)
+	if s.Remaining < 0 {
+		s.Remaining = 0
+	}
+	s.UpdatedAt = now
+
+	return s
+}
+
+func getPath() string {
+	path, _ := os.UserCacheDir()
+
+	return filepath.Join(path, folder, subdir)
+}
+
+func getFullPath(pid int) string {
+	return filepath.Join(getPath(), strconv.Itoa(pid)+ext)
+}
+
+// Write publishes the state of this process.
+func Write(state State) error {
+	state.PID = os.Getpid()
+
+	bytes, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(getPath(), 0700)
+	if err != nil {
+		return err
+	}
+
+	tmp := getFullPath(state.PID) + ".tmp"
+
+	err = os.WriteFile(tmp, bytes, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, getFullPath(state.PID))
+}
+
+// Read returns the state of the instance running the profile, of any
+// instance for an empty profile. A running timer is preferred, then the
+// latest state. It is nil when no instance is running, files left behind
+// by instances that are gone are removed.
+func Read(profile string) (*State, error) {
+	entries, err := os.ReadDir(getPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var found *State
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ext))
+		if err != nil || !strings.HasSuffix(entry.Name(), ext) {
+			continue
+		}
+
+		if !alive(pid) {
+			_ = os.Remove(getFullPath(pid))
+			continue
+		}
+
+		state, err := readFile(pid)
+		if err != nil {
+			return nil, err
+		}
+
+		if profile != "" && state.Profile != profile {
+			continue
+		}
+
+		if found == nil || newer(state, *found) {
+			found = &state
+		}
+	}
+
+	return found, nil
+}
+
+func readFile(pid int) (State, error) {
+	var state State
+
+	file, err := os.ReadFile(getFullPath(pid))
+	if err != nil {
+		return state, err
+	}
+
+	err = json.Unmarshal(file, &state)
+
+	return state, err
+}
+
+func newer(s, other State) bool {
+	if s.Running != other.Running {
+		return s.Running
+	}
+
+	return s.UpdatedAt.After(other.UpdatedAt)
+}
+
+// alive reports whether the process pid exists. On Windows FindProcess
+// already fails for a process that has exited, elsewhere the process is
+// sent the null signal.
+func alive(pid int) bool {
+	// 0 and -1 would signal a group of processes
+	if pid <= 0 {
+		return false
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		_ = process.Release()
+		return true
+	}
+
+	err = process.Signal(syscall.Signal(0))
+
+	return err == nil || errors.Is(err, os.ErrPermission)
+}
+
+// Remove clears the state of this process, it is called when the instance
+// exits.
+func Remove() error {
+	err := os.Remove(getFullPath(os.Getpid()))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,97 @@
+package status
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	running := State{Profile: "default", Running: true, Remaining: 25 * time.Minute, UpdatedAt: start}
+	paused := State{Profile: "default", Remaining: 25 * time.Minute, UpdatedAt: start}
+	countingUp := State{Profile: "default", Running: true, CountingUp: true, Remaining: time.Minute, UpdatedAt: start}
+
+	later := func(s State, elapsed, remaining time.Duration) State {
+		s.UpdatedAt = s.UpdatedAt.Add(elapsed)
+		s.Remaining = remaining
+
+		return s
+	}
+
+	tests := []struct {
+		name  string
+		s     State
+		other State
+		want  bool
+	}{
+		{name: "running clock", s: running, other: later(running, 10*time.Second, 25*time.Minute-10*time.Second), want: true},
+		{name: "counting up", s: countingUp, other: later(countingUp, 10*time.Second, time.Minute+10*time.Second), want: true},
+		{name: "paused clock", s: paused, other: later(paused, 10*time.Second, 25*time.Minute), want: true},
+		{name: "time added", s: running, other: later(running, 10*time.Second, 30*time.Minute), want: false},
+		{name: "paused", s: running, other: later(paused, 10*time.Second, 25*time.Minute-10*time.Second), want: false},
+		{name: "paused clock moved", s: paused, other: later(paused, 10*time.Second, 24*time.Minute), want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.s.Equal(test.other); got != test.want {
+				t.Errorf("Equal = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	if state, err := Read(""); state != nil || err != nil {
+		t.Fatalf("Read without instances = %v, %v, want nil", state, err)
+	}
+
+	if err := Write(State{Profile: "coding", Running: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// no process has a negative PID, its file is left behind
+	if err := os.WriteFile(getFullPath(-1), []byte(`{"Profile":"study"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		profile string
+		want    string
+	}{
+		{profile: "", want: "coding"},
+		{profile: "coding", want: "coding"},
+		{profile: "study", want: ""},
+	}
+
+	for _, test := range tests {
+		state, err := Read(test.profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := ""
+		if state != nil {
+			got = state.Profile
+		}
+
+		if got != test.want {
+			t.Errorf("Read(%q) is the state of %q, want %q", test.profile, got, test.want)
+		}
+	}
+
+	if _, err := os.Stat(getFullPath(-1)); !os.IsNotExist(err) {
+		t.Errorf("the state of a process that is gone was not removed: %v", err)
+	}
+
+	if err := Remove(); err != nil {
+		t.Fatal(err)
+	}
+
+	if state, _ := Read(""); state != nil {
+		t.Errorf("Read after Remove = %v, want nil", state)
+	}
+}
@@ -0,0 +1,19 @@
+package subscriber
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"github.com/borissimkin/pomogoro/pkg/history"
+)
+
+// History records completed sessions in the history of the profile they
+// were counted in.
+func History(bus *event.Bus) {
+	event.On(bus, "history", func(e event.Completed) {
+		_ = history.Append(e.Profile, history.Record{
+			SessionType: e.SessionType,
+			FinishedAt:  e.At,
+			Duration:    e.Duration,
+			Overtime:    e.Overtime,
+		})
+	})
+}
@@ -0,0 +1,88 @@
+// Package subscriber contains the side effects of session events.
+package subscriber
+
+import (
+	"github.com/borissimkin/pomogoro/pkg/config"
+	"github.com/borissimkin/pomogoro/pkg/event"
+	"github.com/borissimkin/pomogoro/pkg/i18n"
+	"github.com/borissimkin/pomogoro/pkg/notification"
+	"github.com/borissimkin/pomogoro/pkg/session"
+	"github.com/borissimkin/pomogoro/pkg/settings"
+)
+
+// notices are the built-in push notifications that announce a session.
+var notices = map[session.Type]notification.NotifyParams{
+	session.Work: {
+		Title:   "Work",
+		Message: "It’s time to focus and make some progress!",
+	},
+	session.Break: {
+		Title:   "Short Break",
+		Message: "Take a short break to recharge and reset.",
+	},
+	session.LongBreak: {
+		Title:   "Long Break",
+		Message: "Enjoy a longer break to fully unwind and refresh.",
+	},
+}
+
+// Notifications announces the next session with a push notification and
+// a sound, as enabled in the latest settings. A session in overtime is
+// announced when it runs out, not again when it is completed.
+func Notifications(bus *event.Bus, s config.Settings, player *notification.Player) {
+	current := s.Notification
+
+	bus.Subscribe("push notification", func(e event.Event) {
+		switch e := e.(type) {
+		case event.SettingsChanged:
+			current = e.Settings.Notification
+		case event.OvertimeStarted:
+			if current.Push {
+				notify(e.Next, current.Messages)
+			}
+		case event.Completed:
+			if current.Push && !e.NotifiedOvertime {
+				notify(e.Next, current.Messages)
+			}
+		}
+	})
+
+	sound := s.Notification.Sound
+	soundFile := s.Notification.SoundFile
+	tone := s.Notification.Tone
+	player.Load(settings.ExpandPath(soundFile), tone)
+
+	bus.Subscribe("sound notification", func(e event.Event) {
+		switch e := e.(type) {
+		case event.SettingsChanged:
+			sound = e.Settings.Notification.Sound
+
+			if e.Settings.Notification.SoundFile != soundFile || e.Settings.Notification.Tone != tone {
+				soundFile = e.Settings.Notification.SoundFile
+				tone = e.Settings.Notification.Tone
+				player.Load(settings.ExpandPath(soundFile), tone)
+			}
+		case event.OvertimeStarted:
+			if sound {
+				player.Play()
+			}
+		case event.Completed:
+			if sound && !e.NotifiedOvertime {
+				player.Play()
+			}
+		}
+	})
+}
+
+// notify announces the next session, messages of the settings replace the
+// built-in ones.
+func notify(next session.Type, messages config.Messages) {
+	notifyParams := notices[next]
+
+	message := i18n.T(notifyParams.Message)
+	if messages[next] != "" {
+		message = messages[next]
+	}
+
+	notification.Notify(i18n.T(notifyParams.Title), message)
+}
@@ -0,0 +1,78 @@
+// Package terminal shows the timer outside of the window with escape
+// sequences: in the title and as the progress of the taskbar button or tab.
+package terminal
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"io"
+	"os"
+	"sync"
+)
+
+// ProgressState is the look of the progress, see Progress.
+type ProgressState int
+
+const (
+	ProgressNone          ProgressState = 0
+	ProgressNormal        ProgressState = 1
+	ProgressError         ProgressState = 2
+	ProgressIndeterminate ProgressState = 3
+	ProgressPaused        ProgressState = 4
+)
+
+const (
+	// SaveTitle pushes the title of the window, RestoreTitle pops it.
+	// Terminals without the title stack, e.g. Windows Terminal, ignore both,
+	// so RestoreTitle first clears the title and the pop puts the saved one
+	// over it where it is supported.
+	SaveTitle    = "\x1b[22;0t"
+	RestoreTitle = "\x1b]2;\x07\x1b[23;0t"
+)
+
+// Progress is the OSC 9;4 sequence of ConEmu, which Windows Terminal,
+// Ghostty and WezTerm understand too. ProgressNone removes the progress.
+func Progress(state ProgressState, percent int) string {
+	return fmt.Sprintf("\x1b]9;4;%d;%d\x07", state, min(max(percent, 0), 100))
+}
+
+// Output is the terminal the program renders to. Writes are serialized,
+// the renderer writes a frame at once, so a sequence sent with Write lands
+// between two frames and never inside one. It is a term.File, so the
+// program still finds the size of the terminal and puts it in raw mode.
+type Output struct {
+	file *os.File
+	mu   sync.Mutex
+}
+
+// Stdout is the Output the program is started with, see tea.WithOutput.
+var Stdout = &Output{file: os.Stdout}
+
+func (o *Output) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.file.Write(p)
+}
+
+func (o *Output) Read(p []byte) (int, error) {
+	return o.file.Read(p)
+}
+
+func (o *Output) Close() error {
+	return o.file.Close()
+}
+
+func (o *Output) Fd() uintptr {
+	return o.file.Fd()
+}
+
+// Write sends seq to the terminal through Stdout from the goroutine of the
+// Cmd. The sequences print nothing and keep the cursor where it is.
+func Write(seq string) tea.Cmd {
+	return func() tea.Msg {
+		_, _ = io.WriteString(Stdout, seq)
+
+		return nil
+	}
+}